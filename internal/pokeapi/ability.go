@@ -0,0 +1,38 @@
+package pokeapi
+
+import "context"
+
+type Ability struct {
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	IsMainSeries  bool             `json:"is_main_series"`
+	EffectEntries []VerboseEffect  `json:"effect_entries"`
+	Generation    NamedAPIResource `json:"generation"`
+	Pokemon       []struct {
+		IsHidden bool             `json:"is_hidden"`
+		Slot     int              `json:"slot"`
+		Pokemon  NamedAPIResource `json:"pokemon"`
+	} `json:"pokemon"`
+}
+
+func (a Ability) Effect(language string) string {
+	if entry, ok := findEffect(a.EffectEntries, language); ok {
+		return cleanGameText(entry.Effect)
+	}
+	return ""
+}
+
+func (a Ability) ShortEffect(language string) string {
+	if entry, ok := findEffect(a.EffectEntries, language); ok {
+		return cleanGameText(entry.ShortEffect)
+	}
+	return ""
+}
+
+func (c *Client) GetAbility(name string) (Ability, error) {
+	return c.GetAbilityContext(context.Background(), name)
+}
+
+func (c *Client) GetAbilityContext(ctx context.Context, name string) (Ability, error) {
+	return fetch[Ability](ctx, c, AbilityResource, c.resourceURL(AbilityResource, name))
+}
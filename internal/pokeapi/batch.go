@@ -0,0 +1,61 @@
+package pokeapi
+
+import (
+	"context"
+	"sync"
+)
+
+const DefaultBatchWorkers = 4
+
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// Batch calls fn for every item using at most workers goroutines and
+// returns the results in the same order as items. Items that had not
+// started when ctx is cancelled get ctx.Err() as their error.
+func Batch[K, T any](ctx context.Context, items []K, workers int, fn func(context.Context, K) (T, error)) []Result[T] {
+	if workers < 1 {
+		workers = 1
+	}
+	results := make([]Result[T], len(items))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, len(items)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					results[i].Err = err
+					continue
+				}
+				results[i].Value, results[i].Err = fn(ctx, items[i])
+			}
+		}()
+	}
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+func (c *Client) SetBatchWorkers(n int) {
+	c.batchWorkers = n
+}
+
+func WithBatchWorkers(n int) Option {
+	return func(c *Client) {
+		c.SetBatchWorkers(n)
+	}
+}
+
+// GetPokemonBatch fetches several pokemon concurrently. Every lookup goes
+// through the cache and the rate limiter just like GetPokemonData.
+func (c *Client) GetPokemonBatch(ctx context.Context, names []string) []Result[Pokemon] {
+	return Batch(ctx, names, c.batchWorkers, c.GetPokemonDataContext)
+}
@@ -0,0 +1,86 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatchPreservesOrder(t *testing.T) {
+	items := []int{5, 1, 4, 2, 3}
+	var running, peak atomic.Int32
+
+	results := Batch(context.Background(), items, 2, func(ctx context.Context, n int) (int, error) {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := peak.Load()
+			if cur <= old || peak.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+		time.Sleep(time.Duration(n) * time.Millisecond)
+		if n == 4 {
+			return 0, errors.New("boom")
+		}
+		return n * 10, nil
+	})
+
+	if peak.Load() > 2 {
+		t.Errorf("expected at most 2 concurrent calls, got %d", peak.Load())
+	}
+	for i, n := range items {
+		if n == 4 {
+			if results[i].Err == nil {
+				t.Errorf("expected error for item %d", i)
+			}
+			continue
+		}
+		if results[i].Err != nil || results[i].Value != n*10 {
+			t.Errorf("item %d: got %+v", i, results[i])
+		}
+	}
+}
+
+func TestBatchCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := Batch(ctx, []string{"a", "b"}, 2, func(ctx context.Context, s string) (string, error) {
+		t.Errorf("expected no calls after cancellation")
+		return s, nil
+	})
+	for _, res := range results {
+		if !errors.Is(res.Err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", res.Err)
+		}
+	}
+}
+
+func TestGetPokemonBatch(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	names := []string{"pikachu", "missingno", "pidgey", "pikachu"}
+	results := client.GetPokemonBatch(context.Background(), names)
+
+	if len(results) != len(names) {
+		t.Fatalf("expected %d results, got %d", len(names), len(results))
+	}
+	if results[0].Err != nil || results[0].Value.Name != "pikachu" {
+		t.Errorf("unexpected first result: %v", results[0].Err)
+	}
+	if !errors.Is(results[1].Err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", results[1].Err)
+	}
+	if results[2].Err != nil || results[2].Value.Name != "pidgey" {
+		t.Errorf("unexpected third result: %v", results[2].Err)
+	}
+	if results[3].Err != nil || results[3].Value.Name != "pikachu" {
+		t.Errorf("unexpected fourth result: %v", results[3].Err)
+	}
+	if srv.Requests() != 3 {
+		t.Errorf("expected duplicate names to share a request, got %d requests", srv.Requests())
+	}
+}
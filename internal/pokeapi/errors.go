@@ -0,0 +1,40 @@
+package pokeapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	ErrNotFound    = errors.New("resource not found")
+	ErrRateLimited = errors.New("rate limited by PokeAPI")
+	ErrDecode      = errors.New("error unmarshalling response body")
+)
+
+// HTTPError is returned for any non-2xx response. It matches ErrNotFound
+// and ErrRateLimited through errors.Is for the corresponding status codes.
+type HTTPError struct {
+	StatusCode int
+	URL        string
+	Body       []byte
+	Attempts   int
+}
+
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("response failed with status code: %d and body: %s", e.StatusCode, e.Body)
+	if e.Attempts > 1 {
+		msg += fmt.Sprintf(" (after %d attempts)", e.Attempts)
+	}
+	return msg
+}
+
+func (e *HTTPError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
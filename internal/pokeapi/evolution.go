@@ -0,0 +1,150 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type EvolutionChain struct {
+	ID              int               `json:"id"`
+	BabyTriggerItem *NamedAPIResource `json:"baby_trigger_item"`
+	Chain           ChainLink         `json:"chain"`
+}
+
+type ChainLink struct {
+	IsBaby           bool              `json:"is_baby"`
+	Species          NamedAPIResource  `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+type EvolutionDetail struct {
+	Trigger               NamedAPIResource  `json:"trigger"`
+	Item                  *NamedAPIResource `json:"item"`
+	Gender                *int              `json:"gender"`
+	HeldItem              *NamedAPIResource `json:"held_item"`
+	KnownMove             *NamedAPIResource `json:"known_move"`
+	KnownMoveType         *NamedAPIResource `json:"known_move_type"`
+	Location              *NamedAPIResource `json:"location"`
+	MinLevel              *int              `json:"min_level"`
+	MinHappiness          *int              `json:"min_happiness"`
+	MinBeauty             *int              `json:"min_beauty"`
+	MinAffection          *int              `json:"min_affection"`
+	NeedsOverworldRain    bool              `json:"needs_overworld_rain"`
+	PartySpecies          *NamedAPIResource `json:"party_species"`
+	PartyType             *NamedAPIResource `json:"party_type"`
+	RelativePhysicalStats *int              `json:"relative_physical_stats"`
+	TimeOfDay             string            `json:"time_of_day"`
+	TradeSpecies          *NamedAPIResource `json:"trade_species"`
+	TurnUpsideDown        bool              `json:"turn_upside_down"`
+}
+
+// String describes the detail the way a player would say it, e.g.
+// "level 16" or "use thunder-stone, during the day".
+func (d EvolutionDetail) String() string {
+	var parts []string
+	switch d.Trigger.Name {
+	case "level-up":
+		if d.MinLevel != nil {
+			parts = append(parts, fmt.Sprintf("level %d", *d.MinLevel))
+		} else {
+			parts = append(parts, "level up")
+		}
+	case "use-item":
+		if d.Item != nil {
+			parts = append(parts, "use "+d.Item.Name)
+		} else {
+			parts = append(parts, "use item")
+		}
+	case "trade":
+		parts = append(parts, "trade")
+		if d.TradeSpecies != nil {
+			parts = append(parts, "for "+d.TradeSpecies.Name)
+		}
+	default:
+		parts = append(parts, d.Trigger.Name)
+	}
+
+	if d.HeldItem != nil {
+		parts = append(parts, "holding "+d.HeldItem.Name)
+	}
+	if d.MinHappiness != nil {
+		parts = append(parts, fmt.Sprintf("happiness %d", *d.MinHappiness))
+	}
+	if d.MinAffection != nil {
+		parts = append(parts, fmt.Sprintf("affection %d", *d.MinAffection))
+	}
+	if d.MinBeauty != nil {
+		parts = append(parts, fmt.Sprintf("beauty %d", *d.MinBeauty))
+	}
+	if d.TimeOfDay != "" {
+		parts = append(parts, "during the "+d.TimeOfDay)
+	}
+	if d.KnownMove != nil {
+		parts = append(parts, "knowing "+d.KnownMove.Name)
+	}
+	if d.KnownMoveType != nil {
+		parts = append(parts, "knowing a "+d.KnownMoveType.Name+" move")
+	}
+	if d.Location != nil {
+		parts = append(parts, "at "+d.Location.Name)
+	}
+	if d.PartySpecies != nil {
+		parts = append(parts, "with "+d.PartySpecies.Name+" in the party")
+	}
+	if d.PartyType != nil {
+		parts = append(parts, "with a "+d.PartyType.Name+" type in the party")
+	}
+	if d.Gender != nil {
+		switch *d.Gender {
+		case 1:
+			parts = append(parts, "female")
+		case 2:
+			parts = append(parts, "male")
+		}
+	}
+	if d.RelativePhysicalStats != nil {
+		switch {
+		case *d.RelativePhysicalStats > 0:
+			parts = append(parts, "attack > defense")
+		case *d.RelativePhysicalStats < 0:
+			parts = append(parts, "attack < defense")
+		default:
+			parts = append(parts, "attack = defense")
+		}
+	}
+	if d.NeedsOverworldRain {
+		parts = append(parts, "while raining")
+	}
+	if d.TurnUpsideDown {
+		parts = append(parts, "with the console upside down")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// EvolutionChainID extracts the chain id from the species' evolution
+// chain link.
+func (s PokemonSpecies) EvolutionChainID() (int, bool) {
+	return resourceID(s.EvolutionChain.URL)
+}
+
+// resourceID returns the trailing numeric id of a PokeAPI resource URL
+// such as https://pokeapi.co/api/v2/evolution-chain/10/.
+func resourceID(url string) (int, bool) {
+	url = strings.TrimSuffix(url, "/")
+	id, err := strconv.Atoi(url[strings.LastIndex(url, "/")+1:])
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+func (c *Client) GetEvolutionChain(id int) (EvolutionChain, error) {
+	return c.GetEvolutionChainContext(context.Background(), id)
+}
+
+func (c *Client) GetEvolutionChainContext(ctx context.Context, id int) (EvolutionChain, error) {
+	return fetch[EvolutionChain](ctx, c, EvolutionChainResource, c.resourceURL(EvolutionChainResource, strconv.Itoa(id)))
+}
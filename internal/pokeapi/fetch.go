@@ -0,0 +1,140 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"pokedexcli/internal/pokecache"
+	"strings"
+)
+
+func (c *Client) resourceURL(parts ...string) string {
+	return strings.TrimSuffix(c.BaseURL, "/") + "/" + strings.Join(parts, "/")
+}
+
+// fetch is the shared pipeline behind every typed getter: serve a fresh
+// cache hit if it decodes, otherwise go to the network, and only ever
+// cache bodies that are valid JSON.
+func fetch[T any](ctx context.Context, c *Client, resource, endpoint string) (T, error) {
+	if cached, ok := c.cache.Get(endpoint); ok {
+		var v T
+		err := json.Unmarshal(cached, &v)
+		if err == nil {
+			return v, nil
+		}
+		c.cache.Remove(endpoint)
+	}
+
+	var v T
+	body, err := c.doGet(ctx, resource, endpoint)
+	if err != nil {
+		return v, err
+	}
+
+	err = json.Unmarshal(body, &v)
+	if err != nil {
+		var zero T
+		return zero, fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+	return v, nil
+}
+
+func (c *Client) doGet(ctx context.Context, resource, endpoint string) ([]byte, error) {
+	stale, hasStale := c.cache.GetEntry(endpoint)
+	if hasStale && c.staleWhileRevalidate {
+		c.background.Add(1)
+		go func() {
+			defer c.background.Done()
+			c.fetchRemote(context.Background(), resource, endpoint)
+		}()
+		c.markStale(endpoint)
+		return stale.Val, nil
+	}
+
+	body, err := c.fetchRemote(ctx, resource, endpoint)
+	if err != nil && hasStale && ctx.Err() == nil && !errors.Is(err, ErrNotFound) {
+		c.markStale(endpoint)
+		return stale.Val, nil
+	}
+	return body, err
+}
+
+func (c *Client) markStale(endpoint string) {
+	if c.OnStale != nil {
+		c.OnStale(endpoint)
+	}
+}
+
+func (c *Client) fetchRemote(ctx context.Context, resource, endpoint string) ([]byte, error) {
+	return c.inflight.Do(ctx, endpoint, func(ctx context.Context) ([]byte, error) {
+		stale, hasStale := c.cache.GetEntry(endpoint)
+		res, body, err := c.get(ctx, endpoint, stale.Validators)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode == http.StatusNotModified {
+			if !hasStale {
+				// Nothing was revalidated, so there is no body to serve
+				// and nothing that may be cached.
+				return nil, &HTTPError{StatusCode: res.StatusCode, URL: endpoint}
+			}
+			c.cache.Touch(endpoint, c.cacheTTL(resource))
+			return stale.Val, nil
+		}
+
+		c.cache.AddWithValidators(endpoint, body, c.cacheTTL(resource), pokecache.Validators{
+			ETag:         res.Header.Get("ETag"),
+			LastModified: res.Header.Get("Last-Modified"),
+		})
+		return body, nil
+	})
+}
+
+// get performs a conditional GET without touching the cache. It succeeds
+// with a JSON body on 2xx and with an empty body on 304.
+func (c *Client) get(ctx context.Context, endpoint string, validators pokecache.Validators) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
+	}
+
+	res, body, attempts, err := c.doWithRetry(ctx, req)
+	if err != nil {
+		if attempts > 1 {
+			return nil, nil, fmt.Errorf("%w (after %d attempts)", err, attempts)
+		}
+		return nil, nil, err
+	}
+
+	if res.StatusCode == http.StatusNotModified {
+		return res, nil, nil
+	}
+
+	if res.StatusCode > 299 {
+		return nil, nil, &HTTPError{
+			StatusCode: res.StatusCode,
+			URL:        endpoint,
+			Body:       body,
+			Attempts:   attempts,
+		}
+	}
+
+	var raw json.RawMessage
+	err = json.Unmarshal(body, &raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+	return res, body, nil
+}
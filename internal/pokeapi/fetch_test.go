@@ -0,0 +1,250 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pokedexcli/internal/pokecache"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const shortTTL = time.Millisecond
+
+// newFailingServer serves pikachu once and then answers every request
+// with status, the way PokeAPI looks while it is down.
+func newFailingServer(t *testing.T, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) > 1 {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"name":"pikachu"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+// newExpiringClient returns a client whose pokemon entries expire almost
+// immediately, and records every endpoint reported through OnStale.
+func newExpiringClient(t *testing.T, baseURL string) (*Client, *[]string) {
+	t.Helper()
+	client := NewClient(time.Minute,
+		WithBaseURL(baseURL),
+		WithCacheTTL(PokemonResource, shortTTL),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+	)
+	t.Cleanup(client.Close)
+	var mu sync.Mutex
+	var stale []string
+	client.OnStale = func(endpoint string) {
+		mu.Lock()
+		defer mu.Unlock()
+		stale = append(stale, endpoint)
+	}
+	return client, &stale
+}
+
+func TestStaleFallback(t *testing.T) {
+	srv, requests := newFailingServer(t, http.StatusInternalServerError)
+	client, stale := newExpiringClient(t, srv.URL)
+
+	if _, err := client.GetPokemonData("pikachu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(5 * shortTTL)
+
+	pokemon, err := client.GetPokemonData("pikachu")
+	if err != nil {
+		t.Fatalf("expected the stale copy, got %v", err)
+	}
+	if pokemon.Name != "pikachu" {
+		t.Errorf("expected pikachu, got %s", pokemon.Name)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("expected 2 requests, got %d", requests.Load())
+	}
+	if want := client.resourceURL(PokemonResource, "pikachu"); len(*stale) != 1 || (*stale)[0] != want {
+		t.Errorf("expected OnStale(%s), got %v", want, *stale)
+	}
+}
+
+func TestStaleFallbackNotFound(t *testing.T) {
+	srv, _ := newFailingServer(t, http.StatusNotFound)
+	client, stale := newExpiringClient(t, srv.URL)
+
+	if _, err := client.GetPokemonData("pikachu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(5 * shortTTL)
+
+	_, err := client.GetPokemonData("pikachu")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound instead of the stale copy, got %v", err)
+	}
+	if len(*stale) != 0 {
+		t.Errorf("expected no OnStale calls, got %v", *stale)
+	}
+}
+
+func TestStaleFallbackCancelled(t *testing.T) {
+	srv, _ := newFailingServer(t, http.StatusInternalServerError)
+	client, stale := newExpiringClient(t, srv.URL)
+
+	if _, err := client.GetPokemonData("pikachu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(5 * shortTTL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.GetPokemonDataContext(ctx, "pikachu")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled instead of the stale copy, got %v", err)
+	}
+	if len(*stale) != 0 {
+		t.Errorf("expected no OnStale calls, got %v", *stale)
+	}
+}
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Write([]byte(`{"name":"pikachu","height":4}`))
+			return
+		}
+		w.Write([]byte(`{"name":"pikachu","height":5}`))
+	}))
+	defer srv.Close()
+	client, stale := newExpiringClient(t, srv.URL)
+	client.SetStaleWhileRevalidate(true)
+
+	if _, err := client.GetPokemonData("pikachu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(5 * shortTTL)
+
+	pokemon, err := client.GetPokemonData("pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pokemon.Height != 4 {
+		t.Errorf("expected the stale copy to be served first, got height %d", pokemon.Height)
+	}
+	if len(*stale) != 1 {
+		t.Errorf("expected 1 OnStale call, got %v", *stale)
+	}
+
+	client.background.Wait()
+	if requests.Load() != 2 {
+		t.Errorf("expected a background request, got %d requests", requests.Load())
+	}
+	entry, ok := client.Cache().GetEntry(client.resourceURL(PokemonResource, "pikachu"))
+	if !ok || string(entry.Val) != `{"name":"pikachu","height":5}` {
+		t.Errorf("expected the revalidated copy in the cache, got %q", entry.Val)
+	}
+}
+
+func TestRevalidateNotModified(t *testing.T) {
+	const etag = `"v1"`
+	const lastModified = "Sat, 17 Oct 2026 12:00:00 GMT"
+	const body = `{"name":"pikachu"}`
+	var requests atomic.Int32
+	var mu sync.Mutex
+	var ifNoneMatch, ifModifiedSince string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		mu.Lock()
+		ifNoneMatch = r.Header.Get("If-None-Match")
+		ifModifiedSince = r.Header.Get("If-Modified-Since")
+		mu.Unlock()
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastModified)
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	cache, err := pokecache.NewDiskCache(time.Minute, dir, pokecache.WithStaleRetention(DefaultStaleRetention))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := NewClient(time.Minute,
+		WithBaseURL(srv.URL),
+		WithCache(cache),
+		WithCacheTTL(PokemonResource, shortTTL),
+	)
+	endpoint := client.resourceURL(PokemonResource, "pikachu")
+
+	if _, err := client.GetPokemonData("pikachu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first, _ := cache.GetEntry(endpoint)
+	time.Sleep(5 * shortTTL)
+
+	pokemon, err := client.GetPokemonData("pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pokemon.Name != "pikachu" {
+		t.Errorf("expected pikachu, got %s", pokemon.Name)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("expected 2 requests, got %d", requests.Load())
+	}
+	mu.Lock()
+	if ifNoneMatch != etag || ifModifiedSince != lastModified {
+		t.Errorf("expected conditional headers, got If-None-Match %q, If-Modified-Since %q", ifNoneMatch, ifModifiedSince)
+	}
+	mu.Unlock()
+
+	second, ok := cache.GetEntry(endpoint)
+	if !ok || string(second.Val) != body {
+		t.Fatalf("expected the cached body to survive the 304, got %q", second.Val)
+	}
+	if !second.ExpiresAt.After(first.ExpiresAt) {
+		t.Errorf("expected the 304 to extend the entry, expiry went from %v to %v", first.ExpiresAt, second.ExpiresAt)
+	}
+	if second.Validators.ETag != etag {
+		t.Errorf("expected validators to be kept, got %+v", second.Validators)
+	}
+	client.Close()
+
+	reopened, err := pokecache.NewDiskCache(time.Minute, dir, pokecache.WithStaleRetention(DefaultStaleRetention))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer reopened.Close()
+	onDisk, ok := reopened.GetEntry(endpoint)
+	if !ok || string(onDisk.Val) != body {
+		t.Errorf("expected the body on disk after the 304, got %q", onDisk.Val)
+	}
+}
+
+func TestNotModifiedWithoutCachedEntry(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer srv.Close()
+	client := NewClient(time.Minute, WithBaseURL(srv.URL))
+	defer client.Close()
+
+	_, err := client.GetPokemonData("pikachu")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotModified {
+		t.Errorf("expected an HTTPError for the unexpected 304, got %v", err)
+	}
+	if _, ok := client.Cache().GetEntry(client.resourceURL(PokemonResource, "pikachu")); ok {
+		t.Errorf("expected nothing to be cached")
+	}
+}
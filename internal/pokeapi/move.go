@@ -0,0 +1,113 @@
+package pokeapi
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Move struct {
+	ID               int                `json:"id"`
+	Name             string             `json:"name"`
+	Accuracy         *int               `json:"accuracy"`
+	EffectChance     *int               `json:"effect_chance"`
+	PP               int                `json:"pp"`
+	Priority         int                `json:"priority"`
+	Power            *int               `json:"power"`
+	DamageClass      NamedAPIResource   `json:"damage_class"`
+	EffectEntries    []VerboseEffect    `json:"effect_entries"`
+	Generation       NamedAPIResource   `json:"generation"`
+	LearnedByPokemon []NamedAPIResource `json:"learned_by_pokemon"`
+	Target           NamedAPIResource   `json:"target"`
+	Type             NamedAPIResource   `json:"type"`
+}
+
+type VerboseEffect struct {
+	Effect      string           `json:"effect"`
+	ShortEffect string           `json:"short_effect"`
+	Language    NamedAPIResource `json:"language"`
+}
+
+// Effect returns the full effect text in the given language with the
+// $effect_chance placeholder filled in.
+func (m Move) Effect(language string) string {
+	if entry, ok := findEffect(m.EffectEntries, language); ok {
+		return m.fillEffectChance(entry.Effect)
+	}
+	return ""
+}
+
+func (m Move) ShortEffect(language string) string {
+	if entry, ok := findEffect(m.EffectEntries, language); ok {
+		return m.fillEffectChance(entry.ShortEffect)
+	}
+	return ""
+}
+
+func (m Move) fillEffectChance(text string) string {
+	if m.EffectChance != nil {
+		text = strings.ReplaceAll(text, "$effect_chance", strconv.Itoa(*m.EffectChance))
+	}
+	return cleanGameText(text)
+}
+
+func findEffect(entries []VerboseEffect, language string) (VerboseEffect, bool) {
+	for _, entry := range entries {
+		if entry.Language.Name == language {
+			return entry, true
+		}
+	}
+	return VerboseEffect{}, false
+}
+
+// LearnedMove is one way a pokemon learns a move in one version group.
+type LearnedMove struct {
+	Move         string
+	Level        int
+	Method       string
+	VersionGroup string
+}
+
+// Learnset lists the moves the pokemon learns, sorted by level and then by
+// name. An empty versionGroup or method matches every version group or
+// learn method.
+func (p Pokemon) Learnset(versionGroup, method string) []LearnedMove {
+	var learnset []LearnedMove
+	for _, move := range p.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if versionGroup != "" && detail.VersionGroup.Name != versionGroup {
+				continue
+			}
+			if method != "" && detail.MoveLearnMethod.Name != method {
+				continue
+			}
+			learnset = append(learnset, LearnedMove{
+				Move:         move.Move.Name,
+				Level:        detail.LevelLearnedAt,
+				Method:       detail.MoveLearnMethod.Name,
+				VersionGroup: detail.VersionGroup.Name,
+			})
+		}
+	}
+	sort.SliceStable(learnset, func(i, j int) bool {
+		if learnset[i].Level != learnset[j].Level {
+			return learnset[i].Level < learnset[j].Level
+		}
+		return learnset[i].Move < learnset[j].Move
+	})
+	return learnset
+}
+
+func (c *Client) GetMove(name string) (Move, error) {
+	return c.GetMoveContext(context.Background(), name)
+}
+
+func (c *Client) GetMoveContext(ctx context.Context, name string) (Move, error) {
+	return fetch[Move](ctx, c, MoveResource, c.resourceURL(MoveResource, name))
+}
+
+// GetMoveBatch fetches several moves concurrently, see GetPokemonBatch.
+func (c *Client) GetMoveBatch(ctx context.Context, names []string) []Result[Move] {
+	return Batch(ctx, names, c.batchWorkers, c.GetMoveContext)
+}
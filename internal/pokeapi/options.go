@@ -0,0 +1,76 @@
+package pokeapi
+
+import (
+	"net/http"
+	"pokedexcli/internal/pokecache"
+	"time"
+)
+
+type Option func(*Client)
+
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.BaseURL = baseURL
+	}
+}
+
+// WithHTTPClient replaces the default HTTP client, e.g. to inject a custom
+// transport. The client's own Timeout is used as-is.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithCache makes the client use cache instead of building its own, in
+// which case the cacheInterval passed to NewClient is ignored.
+func WithCache(cache *pokecache.Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithPokedex lets several clients share the same caught pokemon.
+func WithPokedex(pokedex *Pokedex) Option {
+	return func(c *Client) {
+		c.pokedex = pokedex
+	}
+}
+
+func WithClock(now func() time.Time) Option {
+	return func(c *Client) {
+		c.now = now
+	}
+}
+
+// WithTimeout is applied after every other option, so it also covers a
+// client passed through WithHTTPClient regardless of option order.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = &d
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.SetRetryPolicy(policy)
+	}
+}
+
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) {
+		c.SetRateLimit(requestsPerSecond, burst)
+	}
+}
+
+func WithCacheTTL(resource string, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.SetCacheTTL(resource, ttl)
+	}
+}
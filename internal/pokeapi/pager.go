@@ -0,0 +1,104 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+var (
+	ErrNoNextPage     = errors.New("you are already at the last page")
+	ErrNoPreviousPage = errors.New("you are already at the first page")
+)
+
+// Pager walks a PokeAPI list endpoint such as location-area or pokemon
+// page by page. It remembers the current page so Next and Previous move
+// relative to it.
+type Pager struct {
+	client   *Client
+	resource string
+	limit    int
+	page     *NamedAPIResourceList
+}
+
+// NewPager returns a pager over resource with the given page size. A limit
+// of zero uses the API default of 20.
+func (c *Client) NewPager(resource string, limit int) *Pager {
+	return &Pager{
+		client:   c,
+		resource: resource,
+		limit:    limit,
+	}
+}
+
+// Next fetches the page after the current one, or the first page if
+// nothing has been fetched yet.
+func (p *Pager) Next(ctx context.Context) (NamedAPIResourceList, error) {
+	if p.page == nil {
+		return p.Seek(ctx, 0)
+	}
+	if p.page.Next == nil {
+		return NamedAPIResourceList{}, ErrNoNextPage
+	}
+	return p.load(ctx, *p.page.Next)
+}
+
+func (p *Pager) Previous(ctx context.Context) (NamedAPIResourceList, error) {
+	if p.page == nil || p.page.Previous == nil {
+		return NamedAPIResourceList{}, ErrNoPreviousPage
+	}
+	return p.load(ctx, *p.page.Previous)
+}
+
+// Seek jumps to the page starting at offset.
+func (p *Pager) Seek(ctx context.Context, offset int) (NamedAPIResourceList, error) {
+	endpoint := p.client.resourceURL(p.resource)
+	query := url.Values{}
+	if offset > 0 {
+		query.Set("offset", strconv.Itoa(offset))
+	}
+	if p.limit > 0 {
+		query.Set("limit", strconv.Itoa(p.limit))
+	}
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	return p.load(ctx, endpoint)
+}
+
+func (p *Pager) HasNext() bool {
+	return p.page == nil || p.page.Next != nil
+}
+
+func (p *Pager) HasPrevious() bool {
+	return p.page != nil && p.page.Previous != nil
+}
+
+// All walks every page from the start and returns the combined results.
+// It leaves the pager positioned on the last page.
+func (p *Pager) All(ctx context.Context) ([]NamedAPIResource, error) {
+	page, err := p.Seek(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]NamedAPIResource, 0, page.Count)
+	results = append(results, page.Results...)
+	for p.page.Next != nil {
+		page, err = p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, page.Results...)
+	}
+	return results, nil
+}
+
+func (p *Pager) load(ctx context.Context, endpoint string) (NamedAPIResourceList, error) {
+	page, err := fetch[NamedAPIResourceList](ctx, p.client, p.resource, endpoint)
+	if err != nil {
+		return NamedAPIResourceList{}, err
+	}
+	p.page = &page
+	return page, nil
+}
@@ -1,9 +1,7 @@
 package pokeapi
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
+	"context"
 	"net/http"
 	"pokedexcli/internal/pokecache"
 	"sync"
@@ -313,10 +311,53 @@ type LocationAreaDetails struct {
 }
 
 type Client struct {
-	BaseURL    string
-	cache      *pokecache.Cache
-	pokedex    *Pokedex
-	httpClient *http.Client
+	BaseURL string
+	// OnStale, if set, is called whenever a response is served from an
+	// expired cache entry instead of the network.
+	OnStale              func(endpoint string)
+	cache                *pokecache.Cache
+	cacheTTLs            map[string]time.Duration
+	staleWhileRevalidate bool
+	retry                RetryPolicy
+	limiter              *rateLimiter
+	batchWorkers         int
+	inflight             flightGroup
+	background           sync.WaitGroup
+	pokedex              *Pokedex
+	httpClient           *http.Client
+	userAgent            string
+	timeout              *time.Duration
+	now                  func() time.Time
+}
+
+const (
+	LocationAreaResource   = "location-area"
+	PokemonResource        = "pokemon"
+	PokemonSpeciesResource = "pokemon-species"
+	EvolutionChainResource = "evolution-chain"
+	TypeResource           = "type"
+	MoveResource           = "move"
+	AbilityResource        = "ability"
+)
+
+// defaultCacheTTLs only lists the resources that are effectively immutable
+// upstream. Everything else uses DefaultCacheTTL so it is revalidated now
+// and then and eventually reaped from the disk cache.
+var defaultCacheTTLs = map[string]time.Duration{
+	LocationAreaResource: pokecache.NoExpiration,
+	PokemonResource:      pokecache.NoExpiration,
+}
+
+type NamedAPIResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type NamedAPIResourceList struct {
+	Count    int                `json:"count"`
+	Next     *string            `json:"next"`
+	Previous *string            `json:"previous"`
+	Results  []NamedAPIResource `json:"results"`
 }
 
 type LocationArea struct {
@@ -331,120 +372,110 @@ type LocationAreaResponse struct {
 	Results  []LocationArea `json:"results"`
 }
 
-func NewClient(cacheInterval time.Duration) *Client {
-	return &Client{
-		BaseURL:    "https://pokeapi.co/api/v2/",
-		cache:      pokecache.NewCache(cacheInterval),
-		pokedex:    &Pokedex{pokemon: make(map[string]Pokemon)},
-		httpClient: &http.Client{},
-	}
-}
+const (
+	DefaultCacheTTL       = 24 * time.Hour
+	DefaultStaleRetention = 24 * time.Hour
+	DefaultTimeout        = 30 * time.Second
+)
 
-func (c *Client) GetLocationAreas(pageURL *string) (LocationAreaResponse, error) {
-	endpoint := fmt.Sprintf("%s/location-area", c.BaseURL)
-	if pageURL != nil {
-		endpoint = *pageURL
+func NewClient(cacheInterval time.Duration, opts ...Option) *Client {
+	c := &Client{
+		BaseURL:      "https://pokeapi.co/api/v2/",
+		cacheTTLs:    make(map[string]time.Duration),
+		httpClient:   &http.Client{Timeout: DefaultTimeout},
+		retry:        DefaultRetryPolicy,
+		batchWorkers: DefaultBatchWorkers,
+		now:          time.Now,
 	}
-	cache, ok := c.cache.Get(endpoint)
-	if ok {
-		var locationResp LocationAreaResponse
-		err := json.Unmarshal(cache, &locationResp)
-		if err == nil {
-			return locationResp, nil
-		}
+	for resource, ttl := range defaultCacheTTLs {
+		c.cacheTTLs[resource] = ttl
 	}
-	res, err := c.httpClient.Get(endpoint)
-	if err != nil {
-		return LocationAreaResponse{}, fmt.Errorf("error making GET request: %w", err)
+	for _, opt := range opts {
+		opt(c)
 	}
-	defer res.Body.Close()
-
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return LocationAreaResponse{}, fmt.Errorf("error reading response body: %w", err)
+	if c.timeout != nil {
+		c.SetTimeout(*c.timeout)
 	}
-
-	if res.StatusCode > 299 {
-		return LocationAreaResponse{}, fmt.Errorf("response failed with status code: %d and body: %s", res.StatusCode, body)
+	if c.cache == nil {
+		c.cache = pokecache.NewCache(cacheInterval, pokecache.WithStaleRetention(DefaultStaleRetention))
 	}
-
-	c.cache.Add(endpoint, body)
-
-	var locationResp LocationAreaResponse
-	err = json.Unmarshal(body, &locationResp)
-	if err != nil {
-		return LocationAreaResponse{}, fmt.Errorf("error unmarshalling response body: %w", err)
+	if c.pokedex == nil {
+		c.pokedex = NewPokedex()
 	}
+	return c
+}
 
-	return locationResp, nil
+func NewClientWithCache(cache *pokecache.Cache) *Client {
+	return NewClient(cache.Interval(), WithCache(cache))
 }
 
-func (c *Client) GetLocationArea(name string) (LocationAreaDetails, error) {
-	endpoint := fmt.Sprintf("%s/location-area/%s", c.BaseURL, name)
+func NewPokedex() *Pokedex {
+	return &Pokedex{pokemon: make(map[string]Pokemon)}
+}
 
-	if cachedData, ok := c.cache.Get(endpoint); ok {
-		var locationArea LocationAreaDetails
-		err := json.Unmarshal(cachedData, &locationArea)
-		if err == nil {
-			return locationArea, nil
-		}
-	}
-	res, err := c.httpClient.Get(endpoint)
-	if err != nil {
-		return LocationAreaDetails{}, fmt.Errorf("error making GET request: %w", err)
-	}
-	defer res.Body.Close()
+func (c *Client) SetCacheTTL(resource string, ttl time.Duration) {
+	c.cacheTTLs[resource] = ttl
+}
 
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return LocationAreaDetails{}, fmt.Errorf("error reading response body: %w", err)
+func (c *Client) cacheTTL(resource string) time.Duration {
+	ttl, ok := c.cacheTTLs[resource]
+	if !ok {
+		return DefaultCacheTTL
 	}
+	return ttl
+}
 
-	if res.StatusCode > 299 {
-		return LocationAreaDetails{}, fmt.Errorf("response failed with status code: %d and body: %s", res.StatusCode, body)
-	}
+// SetTimeout bounds how long a single HTTP request may take, including
+// reading the response body. Zero means no timeout.
+func (c *Client) SetTimeout(d time.Duration) {
+	// Work on a copy: the http.Client may be shared, e.g. http.DefaultClient
+	// passed through WithHTTPClient.
+	httpClient := *c.httpClient
+	httpClient.Timeout = d
+	c.httpClient = &httpClient
+}
 
-	var locationArea LocationAreaDetails
-	err = json.Unmarshal(body, &locationArea)
-	if err != nil {
-		return LocationAreaDetails{}, fmt.Errorf("error unmarshalling response body: %w", err)
-	}
-	c.cache.Add(endpoint, body)
-	return locationArea, nil
+func (c *Client) Cache() *pokecache.Cache {
+	return c.cache
 }
 
-func (c *Client) GetPokemonData(name string) (Pokemon, error) {
-	endpoint := fmt.Sprintf("%s/pokemon/%s", c.BaseURL, name)
+// SetStaleWhileRevalidate makes expired cache entries be served
+// immediately while a fresh copy is fetched in the background.
+func (c *Client) SetStaleWhileRevalidate(enabled bool) {
+	c.staleWhileRevalidate = enabled
+}
 
-	if cachedData, ok := c.cache.Get(endpoint); ok {
-		var pokemon Pokemon
-		err := json.Unmarshal(cachedData, &pokemon)
-		if err == nil {
-			return pokemon, nil
-		}
-	}
-	res, err := c.httpClient.Get(endpoint)
-	if err != nil {
-		return Pokemon{}, fmt.Errorf("error making GET request: %w", err)
-	}
-	defer res.Body.Close()
+func (c *Client) Close() {
+	c.background.Wait()
+	c.cache.Close()
+}
 
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return Pokemon{}, fmt.Errorf("error reading response body: %w", err)
-	}
+func (c *Client) GetLocationAreas(pageURL *string) (LocationAreaResponse, error) {
+	return c.GetLocationAreasContext(context.Background(), pageURL)
+}
 
-	if res.StatusCode > 299 {
-		return Pokemon{}, fmt.Errorf("response failed with status code: %d and body: %s", res.StatusCode, body)
+func (c *Client) GetLocationAreasContext(ctx context.Context, pageURL *string) (LocationAreaResponse, error) {
+	endpoint := c.resourceURL(LocationAreaResource)
+	if pageURL != nil {
+		endpoint = *pageURL
 	}
+	return fetch[LocationAreaResponse](ctx, c, LocationAreaResource, endpoint)
+}
 
-	var pokemon Pokemon
-	err = json.Unmarshal(body, &pokemon)
-	if err != nil {
-		return Pokemon{}, fmt.Errorf("error unmarshalling response body: %w", err)
-	}
-	c.cache.Add(endpoint, body)
-	return pokemon, nil
+func (c *Client) GetLocationArea(name string) (LocationAreaDetails, error) {
+	return c.GetLocationAreaContext(context.Background(), name)
+}
+
+func (c *Client) GetLocationAreaContext(ctx context.Context, name string) (LocationAreaDetails, error) {
+	return fetch[LocationAreaDetails](ctx, c, LocationAreaResource, c.resourceURL(LocationAreaResource, name))
+}
+
+func (c *Client) GetPokemonData(name string) (Pokemon, error) {
+	return c.GetPokemonDataContext(context.Background(), name)
+}
+
+func (c *Client) GetPokemonDataContext(ctx context.Context, name string) (Pokemon, error) {
+	return fetch[Pokemon](ctx, c, PokemonResource, c.resourceURL(PokemonResource, name))
 }
 
 func (c *Client) AddToPokedex(pokemon Pokemon) {
@@ -469,3 +500,12 @@ func (c *Client) GetPokedex() []Pokemon {
 	}
 	return pokedex
 }
+
+func (c *Client) SetPokedex(pokemon []Pokemon) {
+	c.pokedex.mu.Lock()
+	defer c.pokedex.mu.Unlock()
+	c.pokedex.pokemon = make(map[string]Pokemon, len(pokemon))
+	for _, p := range pokemon {
+		c.pokedex.pokemon[p.Name] = p
+	}
+}
@@ -0,0 +1,712 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"pokedexcli/internal/pokeapitest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T) (*Client, *pokeapitest.Server) {
+	t.Helper()
+	srv := pokeapitest.NewServer()
+	t.Cleanup(srv.Close)
+	client := NewClient(time.Minute, WithBaseURL(srv.BaseURL()))
+	t.Cleanup(client.Close)
+	return client, srv
+}
+
+func TestGetLocationAreas(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	res, err := client.GetLocationAreas(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Count != 3 || len(res.Results) != 3 {
+		t.Fatalf("expected 3 location areas, got %d", len(res.Results))
+	}
+	if res.Results[0].Name != "canalave-city-area" {
+		t.Errorf("expected results ordered by id, got %s first", res.Results[0].Name)
+	}
+	if res.Next != nil || res.Previous != nil {
+		t.Errorf("expected a single page")
+	}
+}
+
+func TestGetLocationAreasPagination(t *testing.T) {
+	client, srv := newTestClient(t)
+	for i := 100; i < 125; i++ {
+		body := fmt.Sprintf(`{"id":%d,"name":"route-%d-area","pokemon_encounters":[]}`, i, i)
+		err := srv.AddResource("location-area", []byte(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	first, err := client.GetLocationAreas(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(first.Results) != 20 || first.Next == nil || first.Previous != nil {
+		t.Fatalf("unexpected first page: %d results", len(first.Results))
+	}
+
+	second, err := client.GetLocationAreas(first.Next)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(second.Results) != 8 || second.Next != nil || second.Previous == nil {
+		t.Fatalf("unexpected second page: %d results", len(second.Results))
+	}
+	if second.Results[len(second.Results)-1].Name != "route-124-area" {
+		t.Errorf("unexpected last result: %s", second.Results[len(second.Results)-1].Name)
+	}
+}
+
+func TestGetLocationArea(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	area, err := client.GetLocationArea("canalave-city-area")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(area.PokemonEncounters) != 2 {
+		t.Fatalf("expected 2 encounters, got %d", len(area.PokemonEncounters))
+	}
+	if area.PokemonEncounters[0].Pokemon.Name != "tentacool" {
+		t.Errorf("unexpected encounter: %s", area.PokemonEncounters[0].Pokemon.Name)
+	}
+}
+
+func TestGetPokemonData(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	for _, key := range []string{"pikachu", "25"} {
+		pokemon, err := client.GetPokemonData(key)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if pokemon.Name != "pikachu" || pokemon.BaseExperience != 112 {
+			t.Errorf("unexpected pokemon for %s: %s", key, pokemon.Name)
+		}
+		if len(pokemon.Types) != 1 || pokemon.Types[0].Type.Name != "electric" {
+			t.Errorf("unexpected types for %s", key)
+		}
+	}
+}
+
+func TestGetPokemonDataNotFound(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	_, err := client.GetPokemonData("pikachuu")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != 404 {
+		t.Errorf("expected HTTPError with status 404, got %v", err)
+	}
+}
+
+func TestGetPokemonDataCached(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetPokemonData("pidgey")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if srv.Requests() != 1 {
+		t.Errorf("expected 1 request, got %d", srv.Requests())
+	}
+	if stats := client.Cache().Stats(); stats.Hits != 2 {
+		t.Errorf("expected 2 cache hits, got %d", stats.Hits)
+	}
+}
+
+func TestCacheTTLDefaults(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	if _, err := client.GetPokemonData("pikachu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetPokemonSpecies("pikachu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pokemon, ok := client.Cache().GetEntry(client.resourceURL(PokemonResource, "pikachu"))
+	if !ok || !pokemon.ExpiresAt.IsZero() {
+		t.Errorf("expected pokemon to never expire, got %v", pokemon.ExpiresAt)
+	}
+	species, ok := client.Cache().GetEntry(client.resourceURL(PokemonSpeciesResource, "pikachu"))
+	if !ok || species.ExpiresAt.IsZero() {
+		t.Fatalf("expected species to expire")
+	}
+	if ttl := species.ExpiresAt.Sub(species.CreatedAt); ttl != DefaultCacheTTL {
+		t.Errorf("expected species TTL %v, got %v", DefaultCacheTTL, ttl)
+	}
+
+	client.SetCacheTTL(PokemonSpeciesResource, time.Minute)
+	if ttl := client.cacheTTL(PokemonSpeciesResource); ttl != time.Minute {
+		t.Errorf("expected overridden TTL, got %v", ttl)
+	}
+}
+
+func TestGetPokemonDataConcurrent(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = client.GetPokemonData("tentacool")
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if srv.Requests() != 1 {
+		t.Errorf("expected 1 request, got %d", srv.Requests())
+	}
+}
+
+func TestGetPokemonDataLeaderCancelled(t *testing.T) {
+	var requests atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		w.Write([]byte(`{"name":"pikachu"}`))
+	}))
+	defer srv.Close()
+	client := NewClient(time.Minute, WithBaseURL(srv.URL))
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := client.GetPokemonDataContext(ctx, "pikachu")
+		leaderErr <- err
+	}()
+	<-started
+
+	waiterCtx := newJoinContext(context.Background())
+	waiterErr := make(chan error, 1)
+	go func() {
+		pokemon, err := client.GetPokemonDataContext(waiterCtx, "pikachu")
+		if err == nil && pokemon.Name != "pikachu" {
+			err = fmt.Errorf("expected pikachu, got %s", pokemon.Name)
+		}
+		waiterErr <- err
+	}()
+	<-waiterCtx.joined
+
+	cancel()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the cancelled caller to get context.Canceled, got %v", err)
+	}
+	close(release)
+	if err := <-waiterErr; err != nil {
+		t.Errorf("expected the other caller to succeed, got %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected 1 request, got %d", requests.Load())
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	shared := &http.Client{Timeout: time.Minute}
+	for _, opts := range [][]Option{
+		{WithHTTPClient(shared), WithTimeout(5 * time.Second)},
+		{WithTimeout(5 * time.Second), WithHTTPClient(shared)},
+	} {
+		client := NewClient(time.Minute, opts...)
+		if client.httpClient.Timeout != 5*time.Second {
+			t.Errorf("expected a 5s timeout regardless of option order, got %v", client.httpClient.Timeout)
+		}
+		client.Close()
+	}
+	if shared.Timeout != time.Minute {
+		t.Errorf("expected the shared http.Client to be left alone, got %v", shared.Timeout)
+	}
+
+	client := NewClient(time.Minute, WithHTTPClient(shared))
+	defer client.Close()
+	client.SetTimeout(time.Second)
+	if shared.Timeout != time.Minute || client.httpClient.Timeout != time.Second {
+		t.Errorf("expected SetTimeout to only change the client's copy")
+	}
+}
+
+func TestPokedex(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	pokemon, err := client.GetPokemonData("pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.AddToPokedex(pokemon)
+
+	if _, ok := client.GetFromPokedex("pikachu"); !ok {
+		t.Errorf("expected pikachu in pokedex")
+	}
+	if len(client.GetPokedex()) != 1 {
+		t.Errorf("expected 1 pokemon in pokedex")
+	}
+	client.SetPokedex(nil)
+	if len(client.GetPokedex()) != 0 {
+		t.Errorf("expected empty pokedex")
+	}
+}
+
+func TestRecordReplay(t *testing.T) {
+	srv := pokeapitest.NewServer()
+	dir := t.TempDir()
+
+	recording := NewClient(time.Minute,
+		WithBaseURL(srv.BaseURL()),
+		WithHTTPClient(&http.Client{Transport: pokeapitest.NewRecorder(dir, pokeapitest.ModeRecord)}),
+	)
+	_, err := recording.GetPokemonData("pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error recording: %v", err)
+	}
+	recording.Close()
+	srv.Close()
+
+	replaying := NewClient(time.Minute,
+		WithBaseURL("https://pokeapi.co/api/v2/"),
+		WithHTTPClient(&http.Client{Transport: pokeapitest.NewRecorder(dir, pokeapitest.ModeReplay)}),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+	)
+	defer replaying.Close()
+	pokemon, err := replaying.GetPokemonData("pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error replaying: %v", err)
+	}
+	if pokemon.Name != "pikachu" {
+		t.Errorf("expected pikachu, got %s", pokemon.Name)
+	}
+	_, err = replaying.GetPokemonData("bulbasaur")
+	if err == nil {
+		t.Errorf("expected error for unrecorded request")
+	}
+}
+
+func TestSnapshotOffline(t *testing.T) {
+	client, _ := newTestClient(t)
+	// A limit of its own replaces the slower default snapshot limit.
+	client.SetRateLimit(1000, 1000)
+	dir := t.TempDir()
+
+	written := 0
+	err := client.Snapshot(context.Background(), dir, func(string) {
+		written++
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Seven lists, every named resource stored under its name and its id,
+	// and the unnamed evolution chains under their id only.
+	if written != 7+2*28+3 {
+		t.Errorf("expected 66 files, got %d", written)
+	}
+
+	offline := NewClient(time.Minute, WithSnapshot(dir))
+	defer offline.Close()
+
+	areas, err := offline.GetLocationAreas(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if areas.Count != 3 || len(areas.Results) != 3 {
+		t.Errorf("expected 3 location areas, got %d", len(areas.Results))
+	}
+
+	for _, key := range []string{"pikachu", "25"} {
+		pokemon, err := offline.GetPokemonData(key)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if pokemon.Name != "pikachu" {
+			t.Errorf("expected pikachu, got %s", pokemon.Name)
+		}
+	}
+
+	_, err = offline.GetPokemonData("bulbasaur")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSnapshotRateLimit(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	limiter := client.snapshotLimiter()
+	if limiter == nil {
+		t.Fatalf("expected Snapshot to throttle an unthrottled client")
+	}
+	if limiter.rate != SnapshotRateLimit || limiter.burst != SnapshotBurst {
+		t.Errorf("unexpected snapshot limit: %v/s, burst %v", limiter.rate, limiter.burst)
+	}
+
+	client.SetRateLimit(1, 1)
+	if client.snapshotLimiter() != nil {
+		t.Errorf("expected the client's own limiter to be used")
+	}
+}
+
+func TestSnapshotPagination(t *testing.T) {
+	dir := t.TempDir()
+	var list NamedAPIResourceList
+	for i := 1; i <= 25; i++ {
+		list.Results = append(list.Results, NamedAPIResource{Name: fmt.Sprintf("area-%d", i)})
+	}
+	list.Count = len(list.Results)
+	body, err := json.Marshal(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = writeSnapshotFile(dir, LocationAreaResource, body, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(time.Minute, WithSnapshot(dir))
+	defer client.Close()
+
+	first, err := client.GetLocationAreas(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(first.Results) != 20 || first.Next == nil || first.Previous != nil {
+		t.Fatalf("unexpected first page: %d results", len(first.Results))
+	}
+	second, err := client.GetLocationAreas(first.Next)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(second.Results) != 5 || second.Next != nil || second.Previous == nil {
+		t.Errorf("unexpected second page: %d results", len(second.Results))
+	}
+}
+
+func TestPager(t *testing.T) {
+	client, srv := newTestClient(t)
+	for i := 100; i < 125; i++ {
+		body := fmt.Sprintf(`{"id":%d,"name":"route-%d-area","pokemon_encounters":[]}`, i, i)
+		err := srv.AddResource("location-area", []byte(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx := context.Background()
+
+	pager := client.NewPager(LocationAreaResource, 10)
+	if pager.HasPrevious() || !pager.HasNext() {
+		t.Errorf("unexpected state before first page")
+	}
+	if _, err := pager.Previous(ctx); !errors.Is(err, ErrNoPreviousPage) {
+		t.Errorf("expected ErrNoPreviousPage, got %v", err)
+	}
+
+	first, err := pager.Next(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(first.Results) != 10 || first.Results[0].Name != "canalave-city-area" {
+		t.Fatalf("unexpected first page: %+v", first.Results)
+	}
+
+	second, err := pager.Next(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.Results[0].Name != "route-107-area" {
+		t.Errorf("unexpected second page start: %s", second.Results[0].Name)
+	}
+
+	back, err := pager.Previous(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if back.Results[0].Name != "canalave-city-area" {
+		t.Errorf("expected to be back on the first page, got %s", back.Results[0].Name)
+	}
+
+	last, err := pager.Seek(ctx, 25)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(last.Results) != 3 || pager.HasNext() {
+		t.Errorf("unexpected last page: %d results", len(last.Results))
+	}
+	if _, err := pager.Next(ctx); !errors.Is(err, ErrNoNextPage) {
+		t.Errorf("expected ErrNoNextPage, got %v", err)
+	}
+
+	all, err := client.NewPager(LocationAreaResource, 7).All(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all) != 28 || all[27].Name != "route-124-area" {
+		t.Errorf("unexpected results walking all pages: %d", len(all))
+	}
+}
+
+func TestGetPokemonSpecies(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	species, err := client.GetPokemonSpecies("pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if species.CaptureRate != 190 || species.GrowthRate.Name != "medium" {
+		t.Errorf("unexpected species: %+v", species)
+	}
+	if species.EvolvesFromSpecies == nil || species.EvolvesFromSpecies.Name != "pichu" {
+		t.Errorf("expected pikachu to evolve from pichu")
+	}
+	if species.Genus("en") != "Mouse Pokémon" {
+		t.Errorf("unexpected genus: %s", species.Genus("en"))
+	}
+	want := "When several of these POKéMON gather, their electricity could build and cause lightning storms."
+	if species.FlavorText("en") != want {
+		t.Errorf("unexpected flavor text: %q", species.FlavorText("en"))
+	}
+	if len(species.Varieties) != 2 || !species.Varieties[0].IsDefault {
+		t.Errorf("unexpected varieties: %+v", species.Varieties)
+	}
+}
+
+func TestGetEvolutionChain(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	species, err := client.GetPokemonSpecies("pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	id, ok := species.EvolutionChainID()
+	if !ok || id != 10 {
+		t.Fatalf("expected evolution chain 10, got %d", id)
+	}
+
+	chain, err := client.GetEvolutionChain(id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if chain.Chain.Species.Name != "pichu" || !chain.Chain.IsBaby {
+		t.Errorf("expected chain to start at baby pichu")
+	}
+	pikachu := chain.Chain.EvolvesTo[0]
+	if pikachu.Species.Name != "pikachu" || pikachu.EvolutionDetails[0].String() != "level up, happiness 220" {
+		t.Errorf("unexpected pikachu link: %s", pikachu.EvolutionDetails[0])
+	}
+	raichu := pikachu.EvolvesTo[0]
+	if raichu.Species.Name != "raichu" || raichu.EvolutionDetails[0].String() != "use thunder-stone" {
+		t.Errorf("unexpected raichu link: %s", raichu.EvolutionDetails[0])
+	}
+}
+
+func TestEvolutionDetailString(t *testing.T) {
+	level := 16
+	gender := 1
+	cases := []struct {
+		detail EvolutionDetail
+		want   string
+	}{
+		{
+			detail: EvolutionDetail{Trigger: NamedAPIResource{Name: "level-up"}, MinLevel: &level},
+			want:   "level 16",
+		},
+		{
+			detail: EvolutionDetail{
+				Trigger:   NamedAPIResource{Name: "trade"},
+				HeldItem:  &NamedAPIResource{Name: "metal-coat"},
+				TimeOfDay: "night",
+			},
+			want: "trade, holding metal-coat, during the night",
+		},
+		{
+			detail: EvolutionDetail{
+				Trigger:   NamedAPIResource{Name: "level-up"},
+				KnownMove: &NamedAPIResource{Name: "ancient-power"},
+				Gender:    &gender,
+			},
+			want: "level up, knowing ancient-power, female",
+		},
+	}
+	for _, c := range cases {
+		if got := c.detail.String(); got != c.want {
+			t.Errorf("got %q, want %q", got, c.want)
+		}
+	}
+}
+
+func TestGetType(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	electric, err := client.GetType("electric")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if electric.ID != 13 || electric.MoveDamageClass.Name != "special" {
+		t.Errorf("unexpected type: %+v", electric)
+	}
+	rel := electric.DamageRelations
+	if len(rel.DoubleDamageTo) != 2 || rel.NoDamageTo[0].Name != "ground" {
+		t.Errorf("unexpected damage relations: %+v", rel)
+	}
+
+	_, err = client.GetType("shadow")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestTypeChart(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	chart, err := client.GetTypeChart(context.Background(), "water", "flying")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cases := []struct {
+		attacking string
+		defending []string
+		want      float64
+	}{
+		{"electric", []string{"water", "flying"}, 4},
+		{"ground", []string{"water", "flying"}, 0},
+		{"rock", []string{"water", "flying"}, 2},
+		{"grass", []string{"water", "flying"}, 1},
+		{"fire", []string{"water"}, 0.5},
+		{"steel", []string{"water"}, 0.5},
+		{"normal", []string{"water"}, 1},
+	}
+	for _, c := range cases {
+		if got := chart.Effectiveness(c.attacking, c.defending...); got != c.want {
+			t.Errorf("%s vs %v: got %v, want %v", c.attacking, c.defending, got, c.want)
+		}
+	}
+
+	weak := chart.Weaknesses("water", "flying")
+	if m, ok := weak["ground"]; weak["electric"] != 4 || !ok || m != 0 {
+		t.Errorf("unexpected weaknesses: %v", weak)
+	}
+	if _, ok := weak["ice"]; ok {
+		t.Errorf("neutral matchups should be left out: %v", weak)
+	}
+
+	_, err = client.GetTypeChart(context.Background(), "water", "shadow")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestGetMove(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	move, err := client.GetMove("thunder-shock")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if move.Power == nil || *move.Power != 40 || move.PP != 30 || move.Type.Name != "electric" {
+		t.Errorf("unexpected move: %+v", move)
+	}
+	if move.DamageClass.Name != "special" {
+		t.Errorf("expected special damage class, got %s", move.DamageClass.Name)
+	}
+	if got := move.ShortEffect("en"); got != "Has a 10% chance to paralyze the target." {
+		t.Errorf("unexpected short effect: %q", got)
+	}
+	if got := move.Effect("en"); got != "Inflicts regular damage. Has a 10% chance to paralyze the target." {
+		t.Errorf("unexpected effect: %q", got)
+	}
+
+	_, err = client.GetMove("splash")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestLearnset(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	pikachu, err := client.GetPokemonData("pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		versionGroup, method string
+		want                 []string
+	}{
+		{"red-blue", "", []string{"thunderbolt", "thunder-shock", "thunder-wave", "quick-attack"}},
+		{"x-y", "level-up", []string{"thunder-shock", "quick-attack"}},
+		{"", "machine", []string{"thunderbolt", "thunderbolt"}},
+		{"gold-silver", "", nil},
+	}
+	for _, c := range cases {
+		var got []string
+		for _, learned := range pikachu.Learnset(c.versionGroup, c.method) {
+			got = append(got, learned.Move)
+		}
+		if !slices.Equal(got, c.want) {
+			t.Errorf("Learnset(%q, %q) = %v, want %v", c.versionGroup, c.method, got, c.want)
+		}
+	}
+}
+
+func TestGetAbility(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	ability, err := client.GetAbility("static")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ability.ID != 9 || ability.Generation.Name != "generation-iii" {
+		t.Errorf("unexpected ability: %+v", ability)
+	}
+	if got := ability.ShortEffect("en"); got != "Has a 30% chance of paralyzing attacking Pokémon on contact." {
+		t.Errorf("unexpected short effect: %q", got)
+	}
+	if ability.Effect("fr") != "" {
+		t.Errorf("expected no effect text in an unknown language")
+	}
+	if len(ability.Pokemon) != 1 || ability.Pokemon[0].Pokemon.Name != "pikachu" || ability.Pokemon[0].IsHidden {
+		t.Errorf("unexpected pokemon: %+v", ability.Pokemon)
+	}
+
+	hidden, err := client.GetAbility("31")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hidden.Name != "lightning-rod" || !hidden.Pokemon[0].IsHidden {
+		t.Errorf("expected lightning-rod to be pikachu's hidden ability")
+	}
+
+	_, err = client.GetAbility("wonder-guard")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package pokeapi
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	limiter := newRateLimiter(10, 3)
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		if delay := limiter.reserve(now); delay != 0 {
+			t.Errorf("request %d: expected no delay within burst, got %v", i, delay)
+		}
+	}
+	if delay := limiter.reserve(now); delay != 100*time.Millisecond {
+		t.Errorf("expected 100ms delay after burst, got %v", delay)
+	}
+	if delay := limiter.reserve(now.Add(time.Second)); delay != 0 {
+		t.Errorf("expected tokens to refill, got %v", delay)
+	}
+}
+
+func TestRateLimiterWaitCancel(t *testing.T) {
+	limiter := newRateLimiter(0.001, 1)
+	limiter.Wait(context.Background(), time.Now())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	err := limiter.Wait(ctx, time.Now())
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+}
+
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retry = policy
+}
+
+// backoff returns a full-jitter exponential delay for the given retry,
+// counting from zero.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.BaseDelay << retry
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// doWithRetry sends req, retrying idempotent requests on transport errors,
+// 429 and 5xx responses. The returned response body has already been read
+// and closed; attempts reports how many requests were made.
+func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Response, []byte, int, error) {
+	maxAttempts := c.retry.MaxAttempts
+	if maxAttempts < 1 || req.Method != http.MethodGet {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		res, body, err := c.send(req.Clone(ctx))
+		if attempt >= maxAttempts || ctx.Err() != nil {
+			return res, body, attempt, err
+		}
+
+		var delay time.Duration
+		switch {
+		case err != nil:
+			delay = c.retry.backoff(attempt - 1)
+		case retryableStatus(res.StatusCode):
+			delay = c.retry.backoff(attempt - 1)
+			if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable {
+				// A server asking for more than MaxDelay doesn't get to
+				// stall the caller beyond what the policy allows.
+				if after, ok := parseRetryAfter(res.Header.Get("Retry-After"), c.now()); ok {
+					delay = min(after, c.retry.MaxDelay)
+				}
+			}
+		default:
+			return res, body, attempt, nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return res, body, attempt, err
+		}
+	}
+}
+
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	if c.limiter != nil {
+		err := c.limiter.Wait(req.Context(), c.now())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error making GET request: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	return res, body, nil
+}
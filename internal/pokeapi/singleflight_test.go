@@ -0,0 +1,174 @@
+package pokeapi
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// joinContext reports through joined when Do first waits on it. Do only
+// does that once the caller is registered on a call, so a test can tell
+// that a caller has joined an in-flight call without peeking at internals.
+type joinContext struct {
+	context.Context
+	joined chan struct{}
+	once   sync.Once
+}
+
+func newJoinContext(parent context.Context) *joinContext {
+	return &joinContext{Context: parent, joined: make(chan struct{})}
+}
+
+func (c *joinContext) Done() <-chan struct{} {
+	c.once.Do(func() {
+		close(c.joined)
+	})
+	return c.Context.Done()
+}
+
+func TestFlightGroupCoalesces(t *testing.T) {
+	var g flightGroup
+	var calls atomic.Int32
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	fn := func(context.Context) ([]byte, error) {
+		calls.Add(1)
+		close(started)
+		<-release
+		return []byte("testdata"), nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([][]byte, 5)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results[0], _ = g.Do(context.Background(), "key", fn)
+	}()
+	<-started
+	for i := 1; i < len(results); i++ {
+		ctx := newJoinContext(context.Background())
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = g.Do(ctx, "key", fn)
+		}(i)
+		<-ctx.joined
+	}
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 call, got %d", calls.Load())
+	}
+	for i, res := range results {
+		if string(res) != "testdata" {
+			t.Errorf("result %d: expected shared value, got %q", i, res)
+		}
+	}
+}
+
+func TestFlightGroupWaiterCancel(t *testing.T) {
+	var g flightGroup
+	release := make(chan struct{})
+	started := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		g.Do(context.Background(), "key", func(context.Context) ([]byte, error) {
+			close(started)
+			<-release
+			return []byte("testdata"), nil
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := g.Do(ctx, "key", func(context.Context) ([]byte, error) {
+		t.Errorf("expected waiter not to run its own call")
+		return nil, nil
+	})
+	if err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	close(release)
+	<-done
+}
+
+func TestFlightGroupLeaderCancel(t *testing.T) {
+	var g flightGroup
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := g.Do(leaderCtx, "key", func(ctx context.Context) ([]byte, error) {
+			close(started)
+			select {
+			case <-release:
+				return []byte("testdata"), nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		})
+		leaderErr <- err
+	}()
+	<-started
+
+	waiterRes := make(chan []byte, 1)
+	waiterErr := make(chan error, 1)
+	waiterCtx := newJoinContext(context.Background())
+	go func() {
+		res, err := g.Do(waiterCtx, "key", nil)
+		waiterRes <- res
+		waiterErr <- err
+	}()
+	<-waiterCtx.joined
+
+	cancelLeader()
+	if err := <-leaderErr; err != context.Canceled {
+		t.Errorf("expected leader to get context.Canceled, got %v", err)
+	}
+	close(release)
+	if err := <-waiterErr; err != nil {
+		t.Errorf("expected waiter to succeed, got %v", err)
+	}
+	if res := <-waiterRes; string(res) != "testdata" {
+		t.Errorf("expected shared value, got %q", res)
+	}
+}
+
+func TestFlightGroupAllCallersCancel(t *testing.T) {
+	var g flightGroup
+	cancelled := make(chan struct{})
+	started := make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g.Do(ctx, "key", func(ctx context.Context) ([]byte, error) {
+			close(started)
+			<-ctx.Done()
+			close(cancelled)
+			return nil, ctx.Err()
+		})
+	}()
+	<-started
+	cancel()
+	<-done
+	<-cancelled
+
+	res, err := g.Do(context.Background(), "key", func(context.Context) ([]byte, error) {
+		return []byte("fresh"), nil
+	})
+	if err != nil || string(res) != "fresh" {
+		t.Errorf("expected a fresh call, got %q, %v", res, err)
+	}
+}
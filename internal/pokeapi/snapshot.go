@@ -0,0 +1,253 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"pokedexcli/internal/pokecache"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const snapshotListLimit = 100000
+
+// A full snapshot sends thousands of requests, so unless the client has
+// a rate limit of its own, Snapshot keeps itself to this one.
+const (
+	SnapshotRateLimit = 10
+	SnapshotBurst     = 10
+)
+
+var snapshotResources = []string{
+	LocationAreaResource,
+	PokemonResource,
+	PokemonSpeciesResource,
+	EvolutionChainResource,
+	TypeResource,
+	MoveResource,
+	AbilityResource,
+}
+
+func DefaultSnapshotDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("error finding cache directory: %w", err)
+	}
+	return filepath.Join(dir, "pokedexcli", "snapshot"), nil
+}
+
+// WithSnapshot makes the client read everything from a snapshot directory
+// written by Snapshot instead of talking to PokeAPI.
+func WithSnapshot(dir string) Option {
+	return func(c *Client) {
+		c.httpClient = &http.Client{Transport: &snapshotTransport{dir: dir}}
+		c.retry = RetryPolicy{MaxAttempts: 1}
+	}
+}
+
+// snapshotTransport serves requests from files laid out like the REST API,
+// e.g. pokemon/pikachu/index.json. List endpoints are stored once in full
+// and paginated on the fly.
+type snapshotTransport struct {
+	dir string
+}
+
+func (t *snapshotTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rel := strings.Trim(req.URL.Path, "/")
+	rel = strings.TrimPrefix(rel, "api/v2")
+	rel = strings.Trim(rel, "/")
+
+	if strings.Contains(rel, "..") {
+		return snapshotResponse(req, http.StatusNotFound, []byte("Not Found")), nil
+	}
+	body, err := os.ReadFile(filepath.Join(t.dir, filepath.FromSlash(rel), "index.json"))
+	if err != nil {
+		return snapshotResponse(req, http.StatusNotFound, []byte("Not Found")), nil
+	}
+	if !strings.Contains(rel, "/") {
+		body, err = paginate(req, body)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return snapshotResponse(req, http.StatusOK, body), nil
+}
+
+func snapshotResponse(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+		Body:          io.NopCloser(strings.NewReader(string(body))),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func paginate(req *http.Request, body []byte) ([]byte, error) {
+	var list NamedAPIResourceList
+	err := json.Unmarshal(body, &list)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+
+	query := req.URL.Query()
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+
+	pageURL := func(offset int) *string {
+		u := *req.URL
+		q := u.Query()
+		q.Set("offset", strconv.Itoa(offset))
+		q.Set("limit", strconv.Itoa(limit))
+		u.RawQuery = q.Encode()
+		s := u.String()
+		return &s
+	}
+
+	page := NamedAPIResourceList{Count: len(list.Results), Results: []NamedAPIResource{}}
+	if offset < len(list.Results) {
+		page.Results = list.Results[offset:min(offset+limit, len(list.Results))]
+	}
+	if offset+limit < len(list.Results) {
+		page.Next = pageURL(offset + limit)
+	}
+	if offset > 0 {
+		page.Previous = pageURL(max(offset-limit, 0))
+	}
+	return json.Marshal(page)
+}
+
+// Snapshot crawls every location area, pokemon, species and evolution
+// chain into dir so the client can later run fully offline via
+// WithSnapshot. report, if not nil, is called with the path of every file
+// written.
+func (c *Client) Snapshot(ctx context.Context, dir string, report func(path string)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	limiter := c.snapshotLimiter()
+
+	var mu sync.Mutex
+	written := func(path string) {
+		if report == nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		report(path)
+	}
+
+	for _, resource := range snapshotResources {
+		endpoint := c.resourceURL(resource) + "?limit=" + strconv.Itoa(snapshotListLimit)
+		body, err := c.snapshotGet(ctx, limiter, endpoint)
+		if err != nil {
+			return err
+		}
+		err = writeSnapshotFile(dir, resource, body, written)
+		if err != nil {
+			return err
+		}
+
+		var list NamedAPIResourceList
+		err = json.Unmarshal(body, &list)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrDecode, err)
+		}
+		results := Batch(ctx, list.Results, c.batchWorkers, func(ctx context.Context, item NamedAPIResource) (struct{}, error) {
+			err := c.snapshotResource(ctx, limiter, dir, resource, item, written)
+			if err != nil {
+				cancel()
+			}
+			return struct{}{}, err
+		})
+		for _, res := range results {
+			if res.Err != nil {
+				return res.Err
+			}
+		}
+	}
+	return nil
+}
+
+// snapshotLimiter returns the limiter Snapshot waits on before each
+// request, or nil when the client already throttles every request.
+func (c *Client) snapshotLimiter() *rateLimiter {
+	if c.limiter != nil {
+		return nil
+	}
+	return newRateLimiter(SnapshotRateLimit, SnapshotBurst)
+}
+
+// snapshotGet fetches endpoint bypassing the cache, after waiting on
+// limiter if there is one.
+func (c *Client) snapshotGet(ctx context.Context, limiter *rateLimiter, endpoint string) ([]byte, error) {
+	if limiter != nil {
+		err := limiter.Wait(ctx, c.now())
+		if err != nil {
+			return nil, err
+		}
+	}
+	_, body, err := c.get(ctx, endpoint, pokecache.Validators{})
+	return body, err
+}
+
+func (c *Client) snapshotResource(ctx context.Context, limiter *rateLimiter, dir, resource string, item NamedAPIResource, report func(string)) error {
+	// Some lists, like evolution-chain, only link to their items by URL.
+	endpoint := item.URL
+	if item.Name != "" {
+		endpoint = c.resourceURL(resource, item.Name)
+	}
+	body, err := c.snapshotGet(ctx, limiter, endpoint)
+	if err != nil {
+		return err
+	}
+	var meta struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	err = json.Unmarshal(body, &meta)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+
+	// Store under both the name and the id since the API links by id but
+	// the REPL looks things up by name.
+	if meta.Name != "" {
+		err = writeSnapshotFile(dir, path.Join(resource, meta.Name), body, report)
+		if err != nil {
+			return err
+		}
+	}
+	return writeSnapshotFile(dir, path.Join(resource, strconv.Itoa(meta.ID)), body, report)
+}
+
+func writeSnapshotFile(dir, rel string, body []byte, report func(string)) error {
+	target := filepath.Join(dir, filepath.FromSlash(rel), "index.json")
+	err := os.MkdirAll(filepath.Dir(target), 0o755)
+	if err != nil {
+		return fmt.Errorf("error creating snapshot directory: %w", err)
+	}
+	err = os.WriteFile(target, body, 0o644)
+	if err != nil {
+		return fmt.Errorf("error writing snapshot file: %w", err)
+	}
+	if report != nil {
+		report(target)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package pokeapi
+
+import (
+	"context"
+	"strings"
+)
+
+type PokemonSpecies struct {
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	Order          int                `json:"order"`
+	BaseHappiness  int                `json:"base_happiness"`
+	CaptureRate    int                `json:"capture_rate"`
+	GenderRate     int                `json:"gender_rate"`
+	HatchCounter   int                `json:"hatch_counter"`
+	IsBaby         bool               `json:"is_baby"`
+	IsLegendary    bool               `json:"is_legendary"`
+	IsMythical     bool               `json:"is_mythical"`
+	Color          NamedAPIResource   `json:"color"`
+	EggGroups      []NamedAPIResource `json:"egg_groups"`
+	EvolutionChain struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	EvolvesFromSpecies *NamedAPIResource `json:"evolves_from_species"`
+	FlavorTextEntries  []struct {
+		FlavorText string           `json:"flavor_text"`
+		Language   NamedAPIResource `json:"language"`
+		Version    NamedAPIResource `json:"version"`
+	} `json:"flavor_text_entries"`
+	Genera []struct {
+		Genus    string           `json:"genus"`
+		Language NamedAPIResource `json:"language"`
+	} `json:"genera"`
+	Generation NamedAPIResource  `json:"generation"`
+	GrowthRate NamedAPIResource  `json:"growth_rate"`
+	Habitat    *NamedAPIResource `json:"habitat"`
+	Varieties  []struct {
+		IsDefault bool             `json:"is_default"`
+		Pokemon   NamedAPIResource `json:"pokemon"`
+	} `json:"varieties"`
+}
+
+// FlavorText returns the first flavor text in the given language with the
+// line and page breaks from the game text boxes collapsed into spaces.
+func (s PokemonSpecies) FlavorText(language string) string {
+	for _, entry := range s.FlavorTextEntries {
+		if entry.Language.Name == language {
+			return cleanGameText(entry.FlavorText)
+		}
+	}
+	return ""
+}
+
+func (s PokemonSpecies) Genus(language string) string {
+	for _, genus := range s.Genera {
+		if genus.Language.Name == language {
+			return genus.Genus
+		}
+	}
+	return ""
+}
+
+func cleanGameText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func (c *Client) GetPokemonSpecies(name string) (PokemonSpecies, error) {
+	return c.GetPokemonSpeciesContext(context.Background(), name)
+}
+
+func (c *Client) GetPokemonSpeciesContext(ctx context.Context, name string) (PokemonSpecies, error) {
+	return fetch[PokemonSpecies](ctx, c, PokemonSpeciesResource, c.resourceURL(PokemonSpeciesResource, name))
+}
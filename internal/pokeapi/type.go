@@ -0,0 +1,115 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+)
+
+type Type struct {
+	ID              int              `json:"id"`
+	Name            string           `json:"name"`
+	DamageRelations TypeRelations    `json:"damage_relations"`
+	MoveDamageClass NamedAPIResource `json:"move_damage_class"`
+	Pokemon         []struct {
+		Slot    int              `json:"slot"`
+		Pokemon NamedAPIResource `json:"pokemon"`
+	} `json:"pokemon"`
+}
+
+type TypeRelations struct {
+	DoubleDamageFrom []NamedAPIResource `json:"double_damage_from"`
+	DoubleDamageTo   []NamedAPIResource `json:"double_damage_to"`
+	HalfDamageFrom   []NamedAPIResource `json:"half_damage_from"`
+	HalfDamageTo     []NamedAPIResource `json:"half_damage_to"`
+	NoDamageFrom     []NamedAPIResource `json:"no_damage_from"`
+	NoDamageTo       []NamedAPIResource `json:"no_damage_to"`
+}
+
+// TypeChart maps an attacking type to the damage multiplier it deals to
+// each defending type. Pairs that are missing are neutral (1x).
+type TypeChart map[string]map[string]float64
+
+// NewTypeChart builds a chart from the damage relations of types. Both
+// directions of every relation are recorded, so the defender's types alone
+// are enough to answer every question about attacks against it.
+func NewTypeChart(types ...Type) TypeChart {
+	chart := make(TypeChart)
+	for _, t := range types {
+		rel := t.DamageRelations
+		for _, r := range []struct {
+			from, to []NamedAPIResource
+			mult     float64
+		}{
+			{rel.DoubleDamageFrom, rel.DoubleDamageTo, 2},
+			{rel.HalfDamageFrom, rel.HalfDamageTo, 0.5},
+			{rel.NoDamageFrom, rel.NoDamageTo, 0},
+		} {
+			for _, attacker := range r.from {
+				chart.set(attacker.Name, t.Name, r.mult)
+			}
+			for _, defender := range r.to {
+				chart.set(t.Name, defender.Name, r.mult)
+			}
+		}
+	}
+	return chart
+}
+
+func (tc TypeChart) set(attacking, defending string, mult float64) {
+	if tc[attacking] == nil {
+		tc[attacking] = make(map[string]float64)
+	}
+	tc[attacking][defending] = mult
+}
+
+// Effectiveness returns the multiplier for a move of the attacking type
+// hitting a defender with the given types, e.g. 4 for electric against
+// water/flying.
+func (tc TypeChart) Effectiveness(attacking string, defending ...string) float64 {
+	mult := 1.0
+	for _, d := range defending {
+		if m, ok := tc[attacking][d]; ok {
+			mult *= m
+		}
+	}
+	return mult
+}
+
+// Weaknesses returns the multiplier of every attacking type in the chart
+// against a defender with the given types. Neutral matchups are left out.
+func (tc TypeChart) Weaknesses(defending ...string) map[string]float64 {
+	out := make(map[string]float64)
+	for attacking := range tc {
+		if m := tc.Effectiveness(attacking, defending...); m != 1 {
+			out[attacking] = m
+		}
+	}
+	return out
+}
+
+func (c *Client) GetType(name string) (Type, error) {
+	return c.GetTypeContext(context.Background(), name)
+}
+
+func (c *Client) GetTypeContext(ctx context.Context, name string) (Type, error) {
+	return fetch[Type](ctx, c, TypeResource, c.resourceURL(TypeResource, name))
+}
+
+// GetTypeChart fetches the named types concurrently and builds a chart
+// from their damage relations.
+func (c *Client) GetTypeChart(ctx context.Context, names ...string) (TypeChart, error) {
+	results := Batch(ctx, names, c.batchWorkers, c.GetTypeContext)
+	types := make([]Type, 0, len(results))
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+			continue
+		}
+		types = append(types, r.Value)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return NewTypeChart(types...), nil
+}
@@ -0,0 +1,208 @@
+package pokeapitest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	apiPrefix    = "/api/v2/"
+	upstreamBase = "https://pokeapi.co/api/v2/"
+	defaultLimit = 20
+)
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// Server is a local stand-in for PokeAPI. It serves fixtures by resource
+// name and numeric id, paginates list endpoints and answers unknown
+// resources with a 404 just like the real API.
+type Server struct {
+	*httptest.Server
+	mu        sync.Mutex
+	resources map[string]map[string]resource
+	requests  atomic.Int64
+}
+
+type resource struct {
+	id   int
+	name string
+	body []byte
+}
+
+type namedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type listResponse struct {
+	Count    int             `json:"count"`
+	Next     *string         `json:"next"`
+	Previous *string         `json:"previous"`
+	Results  []namedResource `json:"results"`
+}
+
+// NewServer starts a server preloaded with the recorded fixtures.
+func NewServer() *Server {
+	s := &Server{
+		resources: make(map[string]map[string]resource),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	err := fs.WalkDir(fixtures, "fixtures", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(p) != ".json" {
+			return err
+		}
+		body, err := fixtures.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return s.AddResource(path.Base(path.Dir(p)), body)
+	})
+	if err != nil {
+		panic(fmt.Sprintf("pokeapitest: loading fixtures: %v", err))
+	}
+	return s
+}
+
+// BaseURL is the value to use as pokeapi.Client.BaseURL.
+func (s *Server) BaseURL() string {
+	return s.URL + apiPrefix
+}
+
+// Requests reports how many requests the server has handled.
+func (s *Server) Requests() int {
+	return int(s.requests.Load())
+}
+
+// AddResource registers body under kind (e.g. "pokemon"). The body must
+// be a JSON object with "id" and "name" fields; links to pokeapi.co
+// inside it are rewritten to point at this server.
+func (s *Server) AddResource(kind string, body []byte) error {
+	var meta struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	err := json.Unmarshal(body, &meta)
+	if err != nil {
+		return fmt.Errorf("error unmarshalling %s fixture: %w", kind, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.resources[kind] == nil {
+		s.resources[kind] = make(map[string]resource)
+	}
+	r := resource{id: meta.ID, name: meta.Name, body: body}
+	if r.name != "" {
+		s.resources[kind][r.name] = r
+	}
+	s.resources[kind][strconv.Itoa(r.id)] = r
+	return nil
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+	if r.Method != http.MethodGet || !strings.HasPrefix(r.URL.Path, apiPrefix) {
+		http.NotFound(w, r)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, apiPrefix), "/"), "/")
+	switch len(parts) {
+	case 1:
+		s.serveList(w, r, parts[0])
+	case 2:
+		s.serveResource(w, parts[0], parts[1])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) serveResource(w http.ResponseWriter, kind, key string) {
+	s.mu.Lock()
+	res, ok := s.resources[kind][strings.ToLower(key)]
+	s.mu.Unlock()
+	if !ok {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	s.writeJSON(w, res.body)
+}
+
+func (s *Server) serveList(w http.ResponseWriter, r *http.Request, kind string) {
+	s.mu.Lock()
+	byKey, ok := s.resources[kind]
+	all := make([]resource, 0, len(byKey))
+	for key, res := range byKey {
+		if key == res.name || res.name == "" {
+			all = append(all, res)
+		}
+	}
+	s.mu.Unlock()
+	if !ok {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].id < all[j].id
+	})
+
+	offset := queryInt(r, "offset", 0)
+	limit := queryInt(r, "limit", defaultLimit)
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	listURL := s.BaseURL() + kind
+
+	page := listResponse{
+		Count:   len(all),
+		Results: []namedResource{},
+	}
+	for i := offset; i < len(all) && i < offset+limit; i++ {
+		page.Results = append(page.Results, namedResource{
+			Name: all[i].name,
+			URL:  fmt.Sprintf("%s/%d/", listURL, all[i].id),
+		})
+	}
+	if offset+limit < len(all) {
+		next := fmt.Sprintf("%s?offset=%d&limit=%d", listURL, offset+limit, limit)
+		page.Next = &next
+	}
+	if offset > 0 {
+		prev := fmt.Sprintf("%s?offset=%d&limit=%d", listURL, max(offset-limit, 0), limit)
+		page.Previous = &prev
+	}
+
+	body, err := json.Marshal(page)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(body)
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, body []byte) {
+	body = []byte(strings.ReplaceAll(string(body), upstreamBase, s.BaseURL()))
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(body)
+}
+
+func queryInt(r *http.Request, name string, fallback int) int {
+	v, err := strconv.Atoi(r.URL.Query().Get(name))
+	if err != nil || v < 0 {
+		return fallback
+	}
+	return v
+}
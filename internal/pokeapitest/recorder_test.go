@@ -0,0 +1,99 @@
+package pokeapitest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecorderRecordReplay(t *testing.T) {
+	srv := NewServer()
+	dir := t.TempDir()
+
+	recorder := &http.Client{Transport: NewRecorder(dir, ModeRecord)}
+	for _, path := range []string{"pokemon/pikachu", "location-area?offset=0&limit=2", "pokemon/missingno"} {
+		res, err := recorder.Get(srv.BaseURL() + path)
+		if err != nil {
+			t.Fatalf("unexpected error recording %s: %v", path, err)
+		}
+		res.Body.Close()
+	}
+	baseURL := srv.BaseURL()
+	srv.Close()
+
+	replayer := &http.Client{Transport: NewRecorder(dir, ModeReplay)}
+	res, err := replayer.Get(baseURL + "pokemon/pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error replaying: %v", err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK || !strings.Contains(string(body), `"name": "pikachu"`) {
+		t.Errorf("unexpected replayed response: %d %s", res.StatusCode, body)
+	}
+
+	res, err = replayer.Get(baseURL + "location-area?offset=0&limit=2")
+	if err != nil {
+		t.Fatalf("unexpected error replaying list: %v", err)
+	}
+	res.Body.Close()
+
+	res, err = replayer.Get(baseURL + "pokemon/missingno")
+	if err != nil {
+		t.Fatalf("unexpected error replaying 404: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusNotFound {
+		t.Errorf("expected replayed 404, got %d", res.StatusCode)
+	}
+
+	_, err = replayer.Get(baseURL + "pokemon/bulbasaur")
+	if err == nil {
+		t.Errorf("expected error for unrecorded request")
+	}
+}
+
+func TestRecorderIgnoresConditionalRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"name":"pikachu"}`))
+	}))
+	defer srv.Close()
+	dir := t.TempDir()
+
+	recorder := &http.Client{Transport: NewRecorder(dir, ModeRecord)}
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/pokemon/pikachu", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i > 0 {
+			req.Header.Set("If-None-Match", `"v1"`)
+		}
+		res, err := recorder.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error recording: %v", err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			t.Errorf("request %d: expected the full response while recording, got %d", i, res.StatusCode)
+		}
+	}
+
+	replayer := &http.Client{Transport: NewRecorder(dir, ModeReplay)}
+	res, err := replayer.Get(srv.URL + "/pokemon/pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error replaying: %v", err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK || string(body) != `{"name":"pikachu"}` {
+		t.Errorf("expected the recorded 200 to survive, got %d %q", res.StatusCode, body)
+	}
+}
@@ -0,0 +1,199 @@
+package pokecache
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// diskStore keeps one file per key. Writes happen in the background, so
+// each one is tagged with a sequence number and only the most recently
+// scheduled write for a key is allowed to land.
+type diskStore struct {
+	dir    string
+	mu     sync.Mutex
+	seq    uint64
+	latest map[string]uint64
+}
+
+// diskHeader is written as a single JSON line ahead of the raw value so
+// reaping only has to read the first line of each file.
+type diskHeader struct {
+	Key          string    `json:"key"`
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+}
+
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("error finding cache directory: %w", err)
+	}
+	return filepath.Join(dir, "pokedexcli"), nil
+}
+
+func newDiskStore(dir string) (*diskStore, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cache directory: %w", err)
+	}
+	return &diskStore{dir: dir, latest: make(map[string]uint64)}, nil
+}
+
+// schedule reserves a write for key that supersedes all earlier ones.
+func (d *diskStore) schedule(key string) uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.seq++
+	d.latest[key] = d.seq
+	return d.seq
+}
+
+func (d *diskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".entry")
+}
+
+func (d *diskStore) read(key string) (cacheEntry, bool) {
+	f, err := os.Open(d.path(key))
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	header, ok := readHeader(r)
+	if !ok || header.Key != key {
+		return cacheEntry{}, false
+	}
+	val, err := io.ReadAll(r)
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	return cacheEntry{
+		createdAt: header.CreatedAt,
+		expiresAt: header.ExpiresAt,
+		val:       val,
+		validators: Validators{
+			ETag:         header.ETag,
+			LastModified: header.LastModified,
+		},
+	}, true
+}
+
+func (d *diskStore) write(key string, entry cacheEntry, seq uint64) error {
+	tmp, err := d.writeTemp(key, entry)
+	if tmp != "" {
+		defer os.Remove(tmp)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.latest[key] != seq {
+		// A newer write for key was scheduled while this one was in
+		// flight. Dropping this one keeps the newer value on disk.
+		return nil
+	}
+	delete(d.latest, key)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, d.path(key))
+}
+
+// writeTemp writes the header and value to a temporary file in the cache
+// directory and returns its name so it can be renamed into place.
+func (d *diskStore) writeTemp(key string, entry cacheEntry) (string, error) {
+	header, err := json.Marshal(diskHeader{
+		Key:          key,
+		CreatedAt:    entry.createdAt,
+		ExpiresAt:    entry.expiresAt,
+		ETag:         entry.validators.ETag,
+		LastModified: entry.validators.LastModified,
+	})
+	if err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(d.dir, ".tmp-*")
+	if err != nil {
+		return "", err
+	}
+	_, err = tmp.Write(append(header, '\n'))
+	if err == nil {
+		_, err = tmp.Write(entry.val)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	return tmp.Name(), err
+}
+
+func (d *diskStore) remove(key string) {
+	os.Remove(d.path(key))
+}
+
+func (d *diskStore) clear() {
+	files, err := os.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+	for _, f := range files {
+		if !f.IsDir() && filepath.Ext(f.Name()) == ".entry" {
+			os.Remove(filepath.Join(d.dir, f.Name()))
+		}
+	}
+}
+
+func (d *diskStore) reap(now time.Time) {
+	files, err := os.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".entry" {
+			continue
+		}
+		path := filepath.Join(d.dir, f.Name())
+		header, ok := readHeaderFile(path)
+		if !ok {
+			os.Remove(path)
+			continue
+		}
+		entry := cacheEntry{expiresAt: header.ExpiresAt}
+		if entry.expired(now) {
+			os.Remove(path)
+		}
+	}
+}
+
+func readHeaderFile(path string) (diskHeader, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return diskHeader{}, false
+	}
+	defer f.Close()
+	return readHeader(bufio.NewReader(f))
+}
+
+func readHeader(r *bufio.Reader) (diskHeader, bool) {
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		return diskHeader{}, false
+	}
+	var header diskHeader
+	err = json.Unmarshal(bytes.TrimSpace(line), &header)
+	if err != nil {
+		return diskHeader{}, false
+	}
+	return header, true
+}
@@ -1,61 +1,353 @@
 package pokecache
 
 import (
+	"container/list"
 	"sync"
 	"time"
 )
 
 type Cache struct {
-	cache    map[string]cacheEntry
-	interval time.Duration
-	mu       sync.Mutex
+	cache      map[string]cacheEntry
+	interval   time.Duration
+	mu         sync.Mutex
+	disk       *diskStore
+	pending    sync.WaitGroup
+	done       chan struct{}
+	stopOnce   sync.Once
+	lru        *list.List
+	size       int
+	maxEntries int
+	maxBytes   int
+	retention  time.Duration
+	hits       uint64
+	misses     uint64
+	evictions  uint64
+}
+
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Bytes     int
+	Entries   int
+}
+
+type EntryInfo struct {
+	Key       string
+	Size      int
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// NoExpiration can be passed to AddWithTTL for entries that should only
+// ever leave the cache through eviction.
+const NoExpiration time.Duration = -1
+
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+type Entry struct {
+	Val        []byte
+	Validators Validators
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+}
+
+func (e Entry) Expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && now.After(e.ExpiresAt)
 }
 
 type cacheEntry struct {
-	createdAt time.Time
-	val       []byte
+	createdAt  time.Time
+	expiresAt  time.Time
+	val        []byte
+	validators Validators
+	elem       *list.Element
+}
+
+func (e cacheEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+func (e cacheEntry) export() Entry {
+	return Entry{
+		Val:        e.val,
+		Validators: e.validators,
+		CreatedAt:  e.createdAt,
+		ExpiresAt:  e.expiresAt,
+	}
+}
+
+type Option func(*Cache)
+
+func WithMaxEntries(n int) Option {
+	return func(c *Cache) {
+		c.maxEntries = n
+	}
+}
+
+func WithMaxBytes(n int) Option {
+	return func(c *Cache) {
+		c.maxBytes = n
+	}
+}
+
+// WithStaleRetention keeps expired entries around for d so they can still
+// be revalidated through GetEntry before the reaper deletes them.
+func WithStaleRetention(d time.Duration) Option {
+	return func(c *Cache) {
+		c.retention = d
+	}
 }
 
-func NewCache(interval time.Duration) *Cache {
+func NewCache(interval time.Duration, opts ...Option) *Cache {
 	c := &Cache{
 		cache:    make(map[string]cacheEntry),
 		interval: interval,
+		done:     make(chan struct{}),
+		lru:      list.New(),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
 	go c.reapLoop()
 	return c
 }
 
+func NewDiskCache(interval time.Duration, dir string, opts ...Option) (*Cache, error) {
+	disk, err := newDiskStore(dir)
+	if err != nil {
+		return nil, err
+	}
+	c := NewCache(interval, opts...)
+	c.disk = disk
+	return c, nil
+}
+
+func (c *Cache) Interval() time.Duration {
+	return c.interval
+}
+
 func (c *Cache) Add(key string, val []byte) {
+	c.AddWithTTL(key, val, c.interval)
+}
+
+func (c *Cache) AddWithTTL(key string, val []byte, ttl time.Duration) {
+	c.AddWithValidators(key, val, ttl, Validators{})
+}
+
+func (c *Cache) AddWithValidators(key string, val []byte, ttl time.Duration, v Validators) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.cache[key] = cacheEntry{
-		createdAt: time.Now(),
-		val:       val}
+	entry := cacheEntry{
+		val:        val,
+		validators: v,
+	}
+	c.store(key, entry, ttl)
 }
 
-func (c *Cache) Get(key string) ([]byte, bool) {
+// Touch restarts the lifetime of an existing entry, typically after the
+// origin confirmed it is still current.
+func (c *Cache) Touch(key string, ttl time.Duration) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	entry, ok := c.cache[key]
+	entry, ok := c.lookup(key)
 	if !ok {
+		return false
+	}
+	c.store(key, entry, ttl)
+	return true
+}
+
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.lookup(key)
+	if !ok || entry.expired(time.Now()) {
+		c.misses++
 		return nil, false
 	}
+	c.hits++
 	return entry.val, true
 }
 
+// GetEntry returns the entry for key along with its validators, including
+// expired entries that are still within the stale retention window.
+func (c *Cache) GetEntry(key string) (Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.lookup(key)
+	if !ok {
+		return Entry{}, false
+	}
+	return entry.export(), true
+}
+
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Bytes:     c.size,
+		Entries:   len(c.cache),
+	}
+}
+
+// List returns the in-memory entries, most recently used first.
+func (c *Cache) List() []EntryInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entries := make([]EntryInfo, 0, len(c.cache))
+	for e := c.lru.Front(); e != nil; e = e.Next() {
+		key := e.Value.(string)
+		entry := c.cache[key]
+		entries = append(entries, EntryInfo{
+			Key:       key,
+			Size:      len(entry.val),
+			CreatedAt: entry.createdAt,
+			ExpiresAt: entry.expiresAt,
+		})
+	}
+	return entries
+}
+
+// Remove deletes key from memory and disk. Pending disk writes are waited
+// for first so they cannot bring the entry back afterwards.
+func (c *Cache) Remove(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.cache[key]
+	c.remove(key)
+	if c.disk != nil {
+		c.pending.Wait()
+		c.disk.remove(key)
+	}
+	return ok
+}
+
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache = make(map[string]cacheEntry)
+	c.lru.Init()
+	c.size = 0
+	if c.disk != nil {
+		c.pending.Wait()
+		c.disk.clear()
+	}
+}
+
+func (c *Cache) Flush() {
+	c.pending.Wait()
+}
+
+func (c *Cache) Close() {
+	c.stopOnce.Do(func() {
+		close(c.done)
+	})
+	c.Flush()
+}
+
+func (c *Cache) lookup(key string) (cacheEntry, bool) {
+	entry, ok := c.cache[key]
+	if ok {
+		c.lru.MoveToFront(entry.elem)
+		return entry, true
+	}
+	if c.disk == nil {
+		return cacheEntry{}, false
+	}
+	entry, ok = c.disk.read(key)
+	if !ok || c.reapable(entry, time.Now()) {
+		return cacheEntry{}, false
+	}
+	// The entry may be evicted again right away if it alone exceeds the
+	// memory limit, so return the copy read from disk.
+	c.set(key, entry)
+	return entry, true
+}
+
+func (c *Cache) reapable(entry cacheEntry, now time.Time) bool {
+	return entry.expired(now.Add(-c.retention))
+}
+
+func (c *Cache) store(key string, entry cacheEntry, ttl time.Duration) {
+	now := time.Now()
+	entry.createdAt = now
+	entry.expiresAt = time.Time{}
+	if ttl != NoExpiration {
+		entry.expiresAt = now.Add(ttl)
+	}
+	c.set(key, entry)
+	if c.disk != nil {
+		seq := c.disk.schedule(key)
+		c.pending.Add(1)
+		go func() {
+			defer c.pending.Done()
+			c.disk.write(key, entry, seq)
+		}()
+	}
+}
+
+func (c *Cache) set(key string, entry cacheEntry) {
+	c.remove(key)
+	entry.elem = c.lru.PushFront(key)
+	c.cache[key] = entry
+	c.size += len(entry.val)
+	c.evict()
+}
+
+func (c *Cache) remove(key string) {
+	entry, ok := c.cache[key]
+	if !ok {
+		return
+	}
+	c.lru.Remove(entry.elem)
+	c.size -= len(entry.val)
+	delete(c.cache, key)
+}
+
+func (c *Cache) evict() {
+	for c.lru.Len() > 0 {
+		overEntries := c.maxEntries > 0 && c.lru.Len() > c.maxEntries
+		overBytes := c.maxBytes > 0 && c.size > c.maxBytes
+		if !overEntries && !overBytes {
+			return
+		}
+		c.remove(c.lru.Back().Value.(string))
+		c.evictions++
+	}
+}
+
 func (c *Cache) reapLoop() {
 	ticker := time.NewTicker(c.interval)
-	for range ticker.C {
-		c.reap()
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.reap()
+		case <-c.done:
+			return
+		}
 	}
 }
 
 func (c *Cache) reap() {
+	now := time.Now()
 	c.mu.Lock()
-	defer c.mu.Unlock()
 	for k, v := range c.cache {
-		if time.Since(v.createdAt) > c.interval {
-			delete(c.cache, k)
+		if c.reapable(v, now) {
+			c.remove(k)
+			c.evictions++
 		}
 	}
+	c.mu.Unlock()
+
+	if c.disk != nil {
+		c.disk.reap(now.Add(-c.retention))
+	}
 }
@@ -25,6 +25,7 @@ func TestAddGet(t *testing.T) {
 	for i, c := range cases {
 		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
 			cache := NewCache(interval)
+			defer cache.Close()
 			cache.Add(c.key, c.val)
 			val, ok := cache.Get(c.key)
 			if !ok {
@@ -43,6 +44,7 @@ func TestReapLoop(t *testing.T) {
 	const baseTime = 5 * time.Millisecond
 	const waitTime = baseTime + 5*time.Millisecond
 	cache := NewCache(baseTime)
+	defer cache.Close()
 	cache.Add("https://example.com", []byte("testdata"))
 
 	_, ok := cache.Get("https://example.com")
@@ -59,3 +61,281 @@ func TestReapLoop(t *testing.T) {
 		return
 	}
 }
+
+func TestDiskCache(t *testing.T) {
+	const interval = 5 * time.Second
+	dir := t.TempDir()
+
+	cache, err := NewDiskCache(interval, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache.Add("https://example.com", []byte("testdata"))
+	cache.Close()
+
+	reopened, err := NewDiskCache(interval, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer reopened.Close()
+	val, ok := reopened.Get("https://example.com")
+	if !ok {
+		t.Errorf("expected to find key on disk")
+		return
+	}
+	if string(val) != "testdata" {
+		t.Errorf("expected to find value")
+		return
+	}
+}
+
+func TestDiskCacheExpired(t *testing.T) {
+	const baseTime = 5 * time.Millisecond
+	dir := t.TempDir()
+
+	cache, err := NewDiskCache(time.Hour, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache.AddWithTTL("https://example.com", []byte("testdata"), baseTime)
+	cache.Close()
+
+	time.Sleep(baseTime * 2)
+
+	reopened, err := NewDiskCache(time.Hour, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer reopened.Close()
+	_, ok := reopened.Get("https://example.com")
+	if ok {
+		t.Errorf("expected to not find expired key")
+		return
+	}
+}
+
+func TestDiskCacheLastWriteWins(t *testing.T) {
+	dir := t.TempDir()
+
+	cache, err := NewDiskCache(time.Hour, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	const writes = 200
+	for i := 0; i < writes; i++ {
+		cache.Add("https://example.com", []byte(fmt.Sprintf("testdata-%d", i)))
+	}
+	cache.Close()
+
+	reopened, err := NewDiskCache(time.Hour, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer reopened.Close()
+	val, ok := reopened.Get("https://example.com")
+	if want := fmt.Sprintf("testdata-%d", writes-1); !ok || string(val) != want {
+		t.Errorf("expected %q on disk, got %q", want, val)
+	}
+}
+
+func TestDiskCacheRemove(t *testing.T) {
+	dir := t.TempDir()
+
+	cache, err := NewDiskCache(time.Hour, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 50; i++ {
+		cache.Add(fmt.Sprintf("https://example.com/%d", i), []byte("testdata"))
+	}
+	cache.Clear()
+	cache.Add("https://example.com/evicted", []byte("testdata"))
+	cache.Remove("https://example.com/evicted")
+	cache.Close()
+
+	reopened, err := NewDiskCache(time.Hour, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer reopened.Close()
+	for i := 0; i < 50; i++ {
+		if _, ok := reopened.Get(fmt.Sprintf("https://example.com/%d", i)); ok {
+			t.Errorf("expected cleared key %d to stay off disk", i)
+		}
+	}
+	if _, ok := reopened.Get("https://example.com/evicted"); ok {
+		t.Errorf("expected removed key to stay off disk")
+	}
+}
+
+func TestClose(t *testing.T) {
+	const baseTime = 5 * time.Millisecond
+	const waitTime = baseTime + 5*time.Millisecond
+	cache := NewCache(baseTime)
+	cache.Close()
+	cache.Close()
+
+	cache.Add("https://example.com", []byte("testdata"))
+
+	time.Sleep(waitTime)
+
+	_, ok := cache.GetEntry("https://example.com")
+	if !ok {
+		t.Errorf("expected key to survive after reaper stopped")
+		return
+	}
+}
+
+func TestMaxEntries(t *testing.T) {
+	const interval = 5 * time.Second
+	cache := NewCache(interval, WithMaxEntries(2))
+	defer cache.Close()
+
+	cache.Add("a", []byte("1"))
+	cache.Add("b", []byte("2"))
+	cache.Get("a")
+	cache.Add("c", []byte("3"))
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("expected least recently used key to be evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Errorf("expected recently used key to be kept")
+	}
+	if _, ok := cache.Get("c"); !ok {
+		t.Errorf("expected newest key to be kept")
+	}
+}
+
+func TestMaxBytes(t *testing.T) {
+	const interval = 5 * time.Second
+	cache := NewCache(interval, WithMaxBytes(10))
+	defer cache.Close()
+
+	cache.Add("a", []byte("12345"))
+	cache.Add("b", []byte("12345"))
+	cache.Add("c", []byte("123"))
+
+	if _, ok := cache.Get("a"); ok {
+		t.Errorf("expected oldest key to be evicted")
+	}
+	if _, ok := cache.Get("b"); !ok {
+		t.Errorf("expected key to be kept")
+	}
+
+	cache.Add("b", []byte("1"))
+	cache.Add("d", []byte("123456"))
+	if _, ok := cache.Get("c"); !ok {
+		t.Errorf("expected key to be kept after replacing smaller value")
+	}
+}
+
+func TestDiskCacheEntryLargerThanMaxBytes(t *testing.T) {
+	cache, err := NewDiskCache(time.Minute, t.TempDir(), WithMaxBytes(4))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cache.Close()
+
+	cache.Add("https://example.com", []byte("0123456789"))
+	cache.Flush()
+
+	for i := 0; i < 2; i++ {
+		val, ok := cache.Get("https://example.com")
+		if !ok || string(val) != "0123456789" {
+			t.Errorf("lookup %d: expected the value from disk, got %q, %v", i, val, ok)
+		}
+	}
+	if stats := cache.Stats(); stats.Entries != 0 || stats.Bytes != 0 {
+		t.Errorf("expected the entry to stay out of memory, got %+v", stats)
+	}
+}
+
+func TestAddWithTTL(t *testing.T) {
+	const baseTime = 5 * time.Millisecond
+	const waitTime = 2*baseTime + 5*time.Millisecond
+	cache := NewCache(baseTime)
+	defer cache.Close()
+
+	cache.AddWithTTL("short", []byte("testdata"), baseTime)
+	cache.AddWithTTL("long", []byte("testdata"), time.Hour)
+	cache.AddWithTTL("forever", []byte("testdata"), NoExpiration)
+
+	time.Sleep(waitTime)
+
+	if _, ok := cache.Get("short"); ok {
+		t.Errorf("expected short-lived key to be reaped")
+	}
+	if _, ok := cache.Get("long"); !ok {
+		t.Errorf("expected long-lived key to be kept")
+	}
+	if _, ok := cache.Get("forever"); !ok {
+		t.Errorf("expected non-expiring key to be kept")
+	}
+}
+
+func TestStats(t *testing.T) {
+	const interval = 5 * time.Second
+	cache := NewCache(interval, WithMaxEntries(1))
+	defer cache.Close()
+
+	cache.Add("a", []byte("1234"))
+	cache.Get("a")
+	cache.Get("missing")
+	cache.Add("b", []byte("12"))
+
+	stats := cache.Stats()
+	if stats.Hits != 1 || stats.Misses != 1 || stats.Evictions != 1 {
+		t.Errorf("unexpected counters: %+v", stats)
+	}
+	if stats.Entries != 1 || stats.Bytes != 2 {
+		t.Errorf("unexpected size: %+v", stats)
+	}
+
+	entries := cache.List()
+	if len(entries) != 1 || entries[0].Key != "b" {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+
+	if !cache.Remove("b") {
+		t.Errorf("expected to remove key")
+	}
+	if cache.Remove("b") {
+		t.Errorf("expected key to already be removed")
+	}
+
+	cache.Add("c", []byte("1"))
+	cache.Clear()
+	if stats := cache.Stats(); stats.Entries != 0 || stats.Bytes != 0 {
+		t.Errorf("expected empty cache after clear: %+v", stats)
+	}
+}
+
+func TestStaleRetention(t *testing.T) {
+	const baseTime = 5 * time.Millisecond
+	cache := NewCache(time.Hour, WithStaleRetention(time.Hour))
+	defer cache.Close()
+
+	validators := Validators{ETag: `"abc"`, LastModified: "Mon, 02 Jan 2006 15:04:05 GMT"}
+	cache.AddWithValidators("https://example.com", []byte("testdata"), baseTime, validators)
+
+	time.Sleep(baseTime * 2)
+
+	if _, ok := cache.Get("https://example.com"); ok {
+		t.Errorf("expected expired key to miss")
+	}
+	entry, ok := cache.GetEntry("https://example.com")
+	if !ok {
+		t.Fatalf("expected expired key to be retained")
+	}
+	if entry.Validators != validators || string(entry.Val) != "testdata" {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+
+	if !cache.Touch("https://example.com", time.Hour) {
+		t.Fatalf("expected to touch key")
+	}
+	if _, ok := cache.Get("https://example.com"); !ok {
+		t.Errorf("expected touched key to be fresh")
+	}
+}
@@ -0,0 +1,109 @@
+package savefile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"pokedexcli/internal/pokeapi"
+)
+
+const currentVersion = 1
+
+type saveFile struct {
+	Version int               `json:"version"`
+	Pokemon []json.RawMessage `json:"pokemon"`
+}
+
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("error finding config directory: %w", err)
+	}
+	return filepath.Join(dir, "pokedexcli", "pokedex.json"), nil
+}
+
+func Load(path string) ([]pokeapi.Pokemon, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var save saveFile
+	err = json.Unmarshal(data, &save)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling save file: %w", err)
+	}
+
+	switch save.Version {
+	case 1:
+	default:
+		return nil, fmt.Errorf("unsupported save file version: %d", save.Version)
+	}
+
+	pokemon := make([]pokeapi.Pokemon, 0, len(save.Pokemon))
+	for _, raw := range save.Pokemon {
+		var p pokeapi.Pokemon
+		err := json.Unmarshal(raw, &p)
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshalling saved pokemon: %w", err)
+		}
+		pokemon = append(pokemon, p)
+	}
+	return pokemon, nil
+}
+
+func Save(path string, pokemon []pokeapi.Pokemon) error {
+	save := saveFile{
+		Version: currentVersion,
+		Pokemon: make([]json.RawMessage, 0, len(pokemon)),
+	}
+	for _, p := range pokemon {
+		raw, err := json.Marshal(p)
+		if err != nil {
+			return fmt.Errorf("error marshalling pokemon: %w", err)
+		}
+		save.Pokemon = append(save.Pokemon, raw)
+	}
+
+	data, err := json.MarshalIndent(save, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling save file: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	err = os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return fmt.Errorf("error creating save directory: %w", err)
+	}
+
+	// Write to a temp file in the same directory and rename it over the
+	// old save so a crash mid-write never leaves a truncated file behind.
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("error creating temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("error writing save file: %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return fmt.Errorf("error replacing save file: %w", err)
+	}
+	return nil
+}
+
+func IsNotExist(err error) bool {
+	return errors.Is(err, os.ErrNotExist)
+}
@@ -0,0 +1,75 @@
+package savefile
+
+import (
+	"os"
+	"path/filepath"
+	"pokedexcli/internal/pokeapi"
+	"testing"
+)
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "pokedex.json")
+
+	var pikachu pokeapi.Pokemon
+	pikachu.Name = "pikachu"
+	pikachu.Height = 4
+	var bulbasaur pokeapi.Pokemon
+	bulbasaur.Name = "bulbasaur"
+	bulbasaur.BaseExperience = 64
+
+	err := Save(path, []pokeapi.Pokemon{pikachu, bulbasaur})
+	if err != nil {
+		t.Fatalf("unexpected error saving: %v", err)
+	}
+
+	pokemon, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+	if len(pokemon) != 2 {
+		t.Fatalf("expected 2 pokemon, got %d", len(pokemon))
+	}
+	if pokemon[0].Name != "pikachu" || pokemon[0].Height != 4 {
+		t.Errorf("unexpected first pokemon: %s", pokemon[0].Name)
+	}
+	if pokemon[1].Name != "bulbasaur" || pokemon[1].BaseExperience != 64 {
+		t.Errorf("unexpected second pokemon: %s", pokemon[1].Name)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	if !IsNotExist(err) {
+		t.Errorf("expected not exist error, got %v", err)
+	}
+}
+
+func TestLoadUnknownFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+	data := `{"version":1,"pokemon":[{"name":"mew","removed_field":true}]}`
+	err := os.WriteFile(path, []byte(data), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pokemon, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+	if len(pokemon) != 1 || pokemon[0].Name != "mew" {
+		t.Errorf("expected to load mew")
+	}
+}
+
+func TestLoadUnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+	err := os.WriteFile(path, []byte(`{"version":99,"pokemon":[]}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Load(path)
+	if err == nil {
+		t.Errorf("expected error for unsupported version")
+	}
+}
@@ -2,24 +2,36 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"math/rand"
 	"os"
+	"os/signal"
 	"pokedexcli/internal/pokeapi"
+	"pokedexcli/internal/pokecache"
+	"pokedexcli/internal/savefile"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 type Config struct {
-	Next     *string
-	Previous *string
-	Client   *pokeapi.Client
+	Client        *pokeapi.Client
+	LocationAreas *pokeapi.Pager
+	SavePath      string
+	SnapshotDir   string
+	Offline       bool
 }
 
 type cliCommand struct {
 	name        string
 	description string
-	callback    func(*Config, ...string) error
+	callback    func(context.Context, *Config, ...string) error
 }
 
 func getCommands() map[string]cliCommand {
@@ -64,10 +76,55 @@ func getCommands() map[string]cliCommand {
 			description: 	"Display all caught pokemon",
 			callback: 		commandPokedex,
 		},
+		"species": {
+			name:        "species",
+			description: "Show species details for a pokemon",
+			callback:    commandSpecies,
+		},
+		"evolutions": {
+			name:        "evolutions",
+			description: "Show the evolution chain of a pokemon",
+			callback:    commandEvolutions,
+		},
+		"weakness": {
+			name:        "weakness",
+			description: "Show which attacking types are strong or weak against a pokemon",
+			callback:    commandWeakness,
+		},
+		"moves": {
+			name:        "moves",
+			description: "List the moves a pokemon learns: moves <pokemon> [--version-group X] [--method level-up|machine|egg|tutor]",
+			callback:    commandMoves,
+		},
+		"ability": {
+			name:        "ability",
+			description: "Describe an ability and list the pokemon that have it",
+			callback:    commandAbility,
+		},
+		"cache": {
+			name:        "cache",
+			description: "Inspect the response cache: cache stats|list|clear|evict <key>",
+			callback:    commandCache,
+		},
+		"snapshot": {
+			name:        "snapshot",
+			description: "Download location areas, pokemon, species and evolution chains for offline use, optionally into the given directory",
+			callback:    commandSnapshot,
+		},
+		"save": {
+			name:        "save",
+			description: "Save the pokedex to disk, optionally to the given path",
+			callback:    commandSave,
+		},
+		"load": {
+			name:        "load",
+			description: "Load the pokedex from disk, optionally from the given path",
+			callback:    commandLoad,
+		},
 	}
 }
 
-func commandHelp(cfg *Config, args ...string) error {
+func commandHelp(ctx context.Context, cfg *Config, args ...string) error {
 	commands := getCommands()
 	fmt.Println("Available commands:")
 	for _, cmd := range commands {
@@ -76,14 +133,23 @@ func commandHelp(cfg *Config, args ...string) error {
 	return nil
 }
 
-func commandExit(cfg *Config, args ...string) error {
+func commandExit(ctx context.Context, cfg *Config, args ...string) error {
 	fmt.Println("Exiting program...")
+	shutdown(cfg)
 	os.Exit(0)
 	return nil
 }
 
-func commandMap(cfg *Config, args ...string) error {
-	res, err := cfg.Client.GetLocationAreas(cfg.Next)
+// shutdown saves the Pokedex and flushes the client before the REPL quits.
+func shutdown(cfg *Config) {
+	if err := savePokedex(cfg, cfg.SavePath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving pokedex: %s\n", err)
+	}
+	cfg.Client.Close()
+}
+
+func commandMap(ctx context.Context, cfg *Config, args ...string) error {
+	res, err := cfg.LocationAreas.Next(ctx)
 	if err != nil {
 		return err
 	}
@@ -91,18 +157,11 @@ func commandMap(cfg *Config, args ...string) error {
 	for _, area := range res.Results {
 		fmt.Println(area.Name)
 	}
-
-	cfg.Next = res.Next
-	cfg.Previous = res.Previous
 	return nil
 }
 
-func commandMapb(cfg *Config, args ...string) error {
-	if cfg.Previous == nil {
-		return fmt.Errorf("you are already at the first page")
-	}
-
-	res, err := cfg.Client.GetLocationAreas(cfg.Previous)
+func commandMapb(ctx context.Context, cfg *Config, args ...string) error {
+	res, err := cfg.LocationAreas.Previous(ctx)
 	if err != nil {
 		return err
 	}
@@ -111,18 +170,18 @@ func commandMapb(cfg *Config, args ...string) error {
 	for _, area := range res.Results {
 		fmt.Println(area.Name)
 	}
-
-	cfg.Next = res.Next
-	cfg.Previous = res.Previous
 	return nil
 }
 
-func commandExplore(cfg *Config, args ...string) error {
+func commandExplore(ctx context.Context, cfg *Config, args ...string) error {
 	if len(args) == 0 {
 		return fmt.Errorf("please provide a location area name to explore")
 	}
 	locationAreaName := args[0]
-	locationArea, err := cfg.Client.GetLocationArea(locationAreaName)
+	locationArea, err := cfg.Client.GetLocationAreaContext(ctx, locationAreaName)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no location area named '%s'", locationAreaName)
+	}
 	if err != nil {
 		return err
 	}
@@ -134,27 +193,30 @@ func commandExplore(cfg *Config, args ...string) error {
 	return nil
 }
 
-func commandCatch(cfg *Config, args ...string) error {
+func commandCatch(ctx context.Context, cfg *Config, args ...string) error {
 	if len(args) == 0 {
 		return fmt.Errorf("please provide a pokemon name to catch")
 	}
 	pokemonName := args[0]
 	fmt.Printf("Throwing a pokeball at %s...\n", pokemonName)
-	pokemonData, err := cfg.Client.GetPokemonData(pokemonName)
+	pokemonData, err := cfg.Client.GetPokemonDataContext(ctx, pokemonName)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no Pokemon named '%s'", pokemonName)
+	}
 	if err != nil {
-		return fmt.Errorf("error fetching pokemon data")
+		return fmt.Errorf("error fetching pokemon data: %w", err)
 	}
 	if rand.Intn(pokemonData.BaseExperience)*2 > pokemonData.BaseExperience {
 		fmt.Printf("%s was caught\n", pokemonName)
 		cfg.Client.AddToPokedex(pokemonData)
-		return nil
+		return savePokedex(cfg, cfg.SavePath)
 	} else {
 		fmt.Printf("%s escaped!\n", pokemonName)
 		return nil
 	}
 }
 
-func commandInspect(cfg *Config, args ...string) error {
+func commandInspect(ctx context.Context, cfg *Config, args ...string) error {
 	if len(args) == 0 {
 		return fmt.Errorf("please provide a pokemon name to inspect")
 	}
@@ -174,10 +236,289 @@ func commandInspect(cfg *Config, args ...string) error {
 	for _, typeInfo := range pokemon.Types {
 		fmt.Printf("  - %s\n", typeInfo.Type.Name)
 	}
+	fmt.Println("Abilities:")
+	for _, abilityInfo := range pokemon.Abilities {
+		if abilityInfo.IsHidden {
+			fmt.Printf("  - %s (hidden)\n", abilityInfo.Ability.Name)
+		} else {
+			fmt.Printf("  - %s\n", abilityInfo.Ability.Name)
+		}
+	}
+	return nil
+}
+
+func commandSpecies(ctx context.Context, cfg *Config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("please provide a pokemon name")
+	}
+	name := args[0]
+	species, err := getSpecies(ctx, cfg, name)
+	if err != nil {
+		return err
+	}
+
+	if genus := species.Genus("en"); genus != "" {
+		fmt.Printf("Name: %s (%s)\n", species.Name, genus)
+	} else {
+		fmt.Printf("Name: %s\n", species.Name)
+	}
+	fmt.Printf("Capture rate: %d\n", species.CaptureRate)
+	fmt.Printf("Base happiness: %d\n", species.BaseHappiness)
+	fmt.Printf("Growth rate: %s\n", species.GrowthRate.Name)
+	fmt.Println("Egg groups:")
+	for _, group := range species.EggGroups {
+		fmt.Printf("  - %s\n", group.Name)
+	}
+	fmt.Printf("Legendary: %t\n", species.IsLegendary)
+	fmt.Printf("Mythical: %t\n", species.IsMythical)
+	if species.EvolvesFromSpecies != nil {
+		fmt.Printf("Evolves from: %s\n", species.EvolvesFromSpecies.Name)
+	}
+	fmt.Println("Varieties:")
+	for _, variety := range species.Varieties {
+		if variety.IsDefault {
+			fmt.Printf("  - %s (default)\n", variety.Pokemon.Name)
+		} else {
+			fmt.Printf("  - %s\n", variety.Pokemon.Name)
+		}
+	}
+	if text := species.FlavorText("en"); text != "" {
+		fmt.Println(text)
+	}
+	return nil
+}
+
+func commandEvolutions(ctx context.Context, cfg *Config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("please provide a pokemon name")
+	}
+	species, err := getSpecies(ctx, cfg, args[0])
+	if err != nil {
+		return err
+	}
+	id, ok := species.EvolutionChainID()
+	if !ok {
+		return fmt.Errorf("%s has no evolution chain", species.Name)
+	}
+	chain, err := cfg.Client.GetEvolutionChainContext(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(chainLinkLabel(chain.Chain, species.Name))
+	printEvolutions(chain.Chain.EvolvesTo, species.Name, "")
+	return nil
+}
+
+func printEvolutions(links []pokeapi.ChainLink, current, indent string) {
+	for i, link := range links {
+		branch, childIndent := "├─ ", "│  "
+		if i == len(links)-1 {
+			branch, childIndent = "└─ ", "   "
+		}
+		fmt.Printf("%s%s%s\n", indent, branch, chainLinkLabel(link, current))
+		printEvolutions(link.EvolvesTo, current, indent+childIndent)
+	}
+}
+
+func chainLinkLabel(link pokeapi.ChainLink, current string) string {
+	label := link.Species.Name
+	if link.IsBaby {
+		label += " (baby)"
+	}
+	details := make([]string, 0, len(link.EvolutionDetails))
+	for _, detail := range link.EvolutionDetails {
+		details = append(details, detail.String())
+	}
+	if len(details) > 0 {
+		label += " [" + strings.Join(details, " or ") + "]"
+	}
+	if link.Species.Name == current {
+		label += " <-"
+	}
+	return label
+}
+
+func commandWeakness(ctx context.Context, cfg *Config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("please provide a pokemon name")
+	}
+	name := args[0]
+	pokemon, err := cfg.Client.GetPokemonDataContext(ctx, name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no Pokemon named '%s'", name)
+	}
+	if err != nil {
+		return err
+	}
+
+	types := make([]string, 0, len(pokemon.Types))
+	for _, typeInfo := range pokemon.Types {
+		types = append(types, typeInfo.Type.Name)
+	}
+	chart, err := cfg.Client.GetTypeChart(ctx, types...)
+	if err != nil {
+		return err
+	}
+
+	byMultiplier := make(map[float64][]string)
+	for attacking, mult := range chart.Weaknesses(types...) {
+		byMultiplier[mult] = append(byMultiplier[mult], attacking)
+	}
+	fmt.Printf("%s (%s)\n", pokemon.Name, strings.Join(types, "/"))
+	if len(byMultiplier) == 0 {
+		fmt.Println("Every attacking type is neutral")
+		return nil
+	}
+	for _, mult := range []float64{4, 2, 0.5, 0.25, 0} {
+		attackers := byMultiplier[mult]
+		if len(attackers) == 0 {
+			continue
+		}
+		sort.Strings(attackers)
+		fmt.Printf("  %gx: %s\n", mult, strings.Join(attackers, ", "))
+	}
 	return nil
 }
 
-func commandPokedex(cfg *Config, args ...string) error {
+func commandAbility(ctx context.Context, cfg *Config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("please provide an ability name")
+	}
+	name := args[0]
+	ability, err := cfg.Client.GetAbilityContext(ctx, name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no ability named '%s'", name)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Name: %s\n", ability.Name)
+	fmt.Printf("Generation: %s\n", ability.Generation.Name)
+	if effect := ability.Effect("en"); effect != "" {
+		fmt.Println(effect)
+	}
+	fmt.Println("Pokemon:")
+	for _, holder := range ability.Pokemon {
+		if holder.IsHidden {
+			fmt.Printf("  - %s (hidden)\n", holder.Pokemon.Name)
+		} else {
+			fmt.Printf("  - %s\n", holder.Pokemon.Name)
+		}
+	}
+	return nil
+}
+
+var learnMethods = []string{"level-up", "machine", "egg", "tutor"}
+
+func commandMoves(ctx context.Context, cfg *Config, args ...string) error {
+	var name, versionGroup, method string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		flagName, value, hasValue := strings.Cut(arg, "=")
+		switch flagName {
+		case "--version-group", "--method":
+			if !hasValue {
+				if i+1 >= len(args) {
+					return fmt.Errorf("%s needs a value", flagName)
+				}
+				i++
+				value = args[i]
+			}
+			if flagName == "--method" {
+				method = value
+			} else {
+				versionGroup = value
+			}
+		default:
+			if strings.HasPrefix(arg, "--") {
+				return fmt.Errorf("unknown flag: %s", arg)
+			}
+			name = arg
+		}
+	}
+	if name == "" {
+		return fmt.Errorf("please provide a pokemon name")
+	}
+	if method != "" && !slices.Contains(learnMethods, method) {
+		return fmt.Errorf("unknown learn method '%s', expected one of %s", method, strings.Join(learnMethods, ", "))
+	}
+
+	pokemon, err := cfg.Client.GetPokemonDataContext(ctx, name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no Pokemon named '%s'", name)
+	}
+	if err != nil {
+		return err
+	}
+	learnset := pokemon.Learnset(versionGroup, method)
+	if len(learnset) == 0 {
+		fmt.Printf("%s learns no moves that match\n", pokemon.Name)
+		return nil
+	}
+
+	var names []string
+	for _, learned := range learnset {
+		if !slices.Contains(names, learned.Move) {
+			names = append(names, learned.Move)
+		}
+	}
+	moves := make(map[string]pokeapi.Move, len(names))
+	for i, result := range cfg.Client.GetMoveBatch(ctx, names) {
+		if result.Err != nil {
+			return fmt.Errorf("error fetching move %s: %w", names[i], result.Err)
+		}
+		moves[names[i]] = result.Value
+	}
+
+	fmt.Printf("Moves for %s:\n", pokemon.Name)
+	for _, learned := range learnset {
+		move := moves[learned.Move]
+		how := learned.Method
+		if learned.Method == "level-up" {
+			how = fmt.Sprintf("level %d", learned.Level)
+		}
+		if versionGroup == "" {
+			how += ", " + learned.VersionGroup
+		}
+		fmt.Printf("  - %s (%s)\n", move.Name, how)
+		fmt.Printf("      %s, %s, power %s, accuracy %s, pp %d\n",
+			move.Type.Name, move.DamageClass.Name, optionalStat(move.Power), optionalStat(move.Accuracy), move.PP)
+		if effect := move.ShortEffect("en"); effect != "" {
+			fmt.Printf("      %s\n", effect)
+		}
+	}
+	return nil
+}
+
+// optionalStat formats a move stat that is null for moves it does not
+// apply to, such as the power of status moves.
+func optionalStat(v *int) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.Itoa(*v)
+}
+
+// getSpecies looks name up as a species first and falls back to treating
+// it as a pokemon form, e.g. "pikachu-rock-star", and following its species.
+func getSpecies(ctx context.Context, cfg *Config, name string) (pokeapi.PokemonSpecies, error) {
+	species, err := cfg.Client.GetPokemonSpeciesContext(ctx, name)
+	if !errors.Is(err, pokeapi.ErrNotFound) {
+		return species, err
+	}
+	pokemon, err := cfg.Client.GetPokemonDataContext(ctx, name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return pokeapi.PokemonSpecies{}, fmt.Errorf("no Pokemon named '%s'", name)
+	}
+	if err != nil {
+		return pokeapi.PokemonSpecies{}, err
+	}
+	return cfg.Client.GetPokemonSpeciesContext(ctx, pokemon.Species.Name)
+}
+
+func commandPokedex(ctx context.Context, cfg *Config, args ...string) error {
 	pokedex := cfg.Client.GetPokedex()
 	if len(pokedex) == 0 {
 		fmt.Println("You have not caught any pokemon yet")
@@ -190,15 +531,253 @@ func commandPokedex(cfg *Config, args ...string) error {
 	return nil
 }
 
+func commandSave(ctx context.Context, cfg *Config, args ...string) error {
+	path := cfg.SavePath
+	if len(args) > 0 {
+		path = args[0]
+	}
+	if path == "" {
+		return fmt.Errorf("please provide a path to save to")
+	}
+	err := savePokedex(cfg, path)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Pokedex saved to %s\n", path)
+	return nil
+}
+
+func commandLoad(ctx context.Context, cfg *Config, args ...string) error {
+	path := cfg.SavePath
+	if len(args) > 0 {
+		path = args[0]
+	}
+	if path == "" {
+		return fmt.Errorf("please provide a path to load from")
+	}
+	pokemon, err := savefile.Load(path)
+	if err != nil {
+		return err
+	}
+	cfg.Client.SetPokedex(pokemon)
+	// Autosave follows the loaded file so the next catch or exit does not
+	// write this Pokedex over a different save.
+	cfg.SavePath = path
+	fmt.Printf("Loaded %d pokemon from %s, autosaving to it from now on\n", len(pokemon), path)
+	return nil
+}
+
+func commandCache(ctx context.Context, cfg *Config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("please provide a subcommand: stats, list, clear or evict <key>")
+	}
+	cache := cfg.Client.Cache()
+	switch args[0] {
+	case "stats":
+		stats := cache.Stats()
+		fmt.Printf("Hits: %d\n", stats.Hits)
+		fmt.Printf("Misses: %d\n", stats.Misses)
+		fmt.Printf("Evictions: %d\n", stats.Evictions)
+		fmt.Printf("Entries: %d\n", stats.Entries)
+		fmt.Printf("Bytes: %d\n", stats.Bytes)
+	case "list":
+		entries := cache.List()
+		if len(entries) == 0 {
+			fmt.Println("The cache is empty")
+			return nil
+		}
+		for _, entry := range entries {
+			expires := "never"
+			if !entry.ExpiresAt.IsZero() {
+				expires = time.Until(entry.ExpiresAt).Round(time.Second).String()
+			}
+			fmt.Printf("- %s (%d bytes, expires: %s)\n", entry.Key, entry.Size, expires)
+		}
+	case "clear":
+		cache.Clear()
+		fmt.Println("Cache cleared")
+	case "evict":
+		if len(args) < 2 {
+			return fmt.Errorf("please provide a key to evict")
+		}
+		if !cache.Remove(args[1]) {
+			return fmt.Errorf("no cache entry for %s", args[1])
+		}
+		fmt.Printf("Evicted %s\n", args[1])
+	default:
+		return fmt.Errorf("unknown cache subcommand: %s", args[0])
+	}
+	return nil
+}
+
+func commandSnapshot(ctx context.Context, cfg *Config, args ...string) error {
+	if cfg.Offline {
+		return fmt.Errorf("cannot take a snapshot in offline mode")
+	}
+	dir := cfg.SnapshotDir
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	if dir == "" {
+		return fmt.Errorf("please provide a directory to save the snapshot to")
+	}
+	fmt.Printf("Saving snapshot to %s...\n", dir)
+	files := 0
+	err := cfg.Client.Snapshot(ctx, dir, func(path string) {
+		files++
+		if files%100 == 0 {
+			fmt.Printf("%d files saved\n", files)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Snapshot complete: %d files saved\n", files)
+	return nil
+}
+
+// loadSaveFile restores the Pokedex from path and turns on autosave to it.
+// A save that exists but cannot be read leaves autosave off, so the next
+// catch or exit does not replace it with an empty Pokedex.
+func loadSaveFile(cfg *Config, path string) error {
+	pokemon, err := savefile.Load(path)
+	if err == nil {
+		cfg.Client.SetPokedex(pokemon)
+	} else if !savefile.IsNotExist(err) {
+		return err
+	}
+	cfg.SavePath = path
+	return nil
+}
+
+func savePokedex(cfg *Config, path string) error {
+	if path == "" {
+		return nil
+	}
+	return savefile.Save(path, cfg.Client.GetPokedex())
+}
+
+const maxCacheBytes = 64 << 20
+
+func newClient(cacheInterval time.Duration, clientOpts ...pokeapi.Option) *pokeapi.Client {
+	opts := []pokecache.Option{
+		pokecache.WithMaxBytes(maxCacheBytes),
+		pokecache.WithStaleRetention(pokeapi.DefaultStaleRetention),
+	}
+	cache, err := newDiskCache(cacheInterval, opts...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening disk cache, using memory only: %s\n", err)
+		cache = pokecache.NewCache(cacheInterval, opts...)
+	}
+	clientOpts = append([]pokeapi.Option{
+		pokeapi.WithCache(cache),
+		pokeapi.WithTimeout(10 * time.Second),
+	}, clientOpts...)
+	return pokeapi.NewClient(cacheInterval, clientOpts...)
+}
+
+func newDiskCache(cacheInterval time.Duration, opts ...pokecache.Option) (*pokecache.Cache, error) {
+	dir, err := pokecache.DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return pokecache.NewDiskCache(cacheInterval, dir, opts...)
+}
+
+// interrupter turns Ctrl-C into cancellation of the running command
+// instead of killing the whole REPL.
+type interrupter struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+func (i *interrupter) start() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	i.mu.Lock()
+	i.cancel = cancel
+	i.mu.Unlock()
+	return ctx, func() {
+		i.mu.Lock()
+		i.cancel = nil
+		i.mu.Unlock()
+		cancel()
+	}
+}
+
+func (i *interrupter) listen() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	for range signals {
+		i.mu.Lock()
+		if i.cancel != nil {
+			i.cancel()
+		} else {
+			fmt.Print("\nType 'exit' to quit\nPokedex > ")
+		}
+		i.mu.Unlock()
+	}
+}
+
 func main() {
+	defaultSnapshotDir, snapshotDirErr := pokeapi.DefaultSnapshotDir()
+	offline := flag.Bool("offline", false, "read everything from the local snapshot instead of PokeAPI")
+	snapshotDir := flag.String("snapshot-dir", defaultSnapshotDir, "directory holding the offline snapshot")
+	flag.Parse()
+
+	if *snapshotDir == "" && snapshotDirErr != nil {
+		fmt.Fprintf(os.Stderr, "Error finding snapshot directory: %s\n", snapshotDirErr)
+	}
+
+	var clientOpts []pokeapi.Option
+	if *offline {
+		if *snapshotDir == "" {
+			fmt.Fprintln(os.Stderr, "Offline mode needs a snapshot, pass -snapshot-dir")
+			os.Exit(1)
+		}
+		if _, err := os.Stat(*snapshotDir); err != nil {
+			fmt.Fprintf(os.Stderr, "No snapshot found in %s, run 'snapshot' while online first\n", *snapshotDir)
+		}
+		clientOpts = append(clientOpts, pokeapi.WithSnapshot(*snapshotDir))
+	}
+
 	scanner := bufio.NewScanner(os.Stdin)
+	client := newClient(10*time.Second, clientOpts...)
 	cfg := &Config{
-		Client: pokeapi.NewClient(10 * time.Second),
+		Client:        client,
+		LocationAreas: client.NewPager(pokeapi.LocationAreaResource, 0),
+		SnapshotDir:   *snapshotDir,
+		Offline:       *offline,
+	}
+	cfg.Client.OnStale = func(endpoint string) {
+		fmt.Printf("(stale) showing cached data for %s\n", endpoint)
+	}
+
+	savePath, err := savefile.DefaultPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error finding save file: %s\n", err)
 	}
+	if savePath != "" {
+		if err := loadSaveFile(cfg, savePath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading pokedex: %s\n", err)
+			fmt.Fprintf(os.Stderr, "Autosave is off so %s is not overwritten, use 'save <path>' to save\n", savePath)
+		}
+	}
+
+	interrupts := &interrupter{}
+	go interrupts.listen()
+
 	cmd := getCommands()
 	for {
 		fmt.Print("Pokedex > ")
-		scanner.Scan()
+		if !scanner.Scan() {
+			// Ctrl-D or the end of piped input.
+			fmt.Println()
+			if err := scanner.Err(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading input: %s\n", err)
+			}
+			shutdown(cfg)
+			return
+		}
 		input := scanner.Text()
 
 		words := strings.Fields(input)
@@ -211,8 +790,12 @@ func main() {
 
 		cmd, ok := cmd[commandName]
 		if ok {
-			err := cmd.callback(cfg, args...)
-			if err != nil {
+			ctx, cancel := interrupts.start()
+			err := cmd.callback(ctx, cfg, args...)
+			cancel()
+			if errors.Is(err, context.Canceled) {
+				fmt.Println("\nCommand cancelled")
+			} else if err != nil {
 				fmt.Fprintf(os.Stderr, "Error executing command: %s\n", err)
 			}
 		} else {
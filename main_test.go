@@ -0,0 +1,466 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"pokedexcli/internal/pokeapi"
+	"pokedexcli/internal/pokeapitest"
+	"pokedexcli/internal/savefile"
+	"strings"
+	"testing"
+	"time"
+)
+
+// captureStdout runs fn and returns everything it printed to stdout.
+func captureStdout(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() {
+		os.Stdout = stdout
+	}()
+
+	out := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		out <- string(b)
+	}()
+	err = fn()
+	w.Close()
+	return <-out, err
+}
+
+func newTestConfig(t *testing.T) *Config {
+	t.Helper()
+	srv := pokeapitest.NewServer()
+	t.Cleanup(srv.Close)
+	client := pokeapi.NewClient(time.Minute, pokeapi.WithBaseURL(srv.BaseURL()))
+	t.Cleanup(client.Close)
+	return &Config{
+		Client:        client,
+		LocationAreas: client.NewPager(pokeapi.LocationAreaResource, 0),
+	}
+}
+
+func TestCommandMap(t *testing.T) {
+	cfg := newTestConfig(t)
+	ctx := context.Background()
+
+	err := commandMap(ctx, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.LocationAreas.HasNext() || cfg.LocationAreas.HasPrevious() {
+		t.Errorf("expected a single page of location areas")
+	}
+	err = commandMapb(ctx, cfg)
+	if err == nil {
+		t.Errorf("expected error on first page")
+	}
+}
+
+func TestCommandExplore(t *testing.T) {
+	cfg := newTestConfig(t)
+	ctx := context.Background()
+
+	err := commandExplore(ctx, cfg, "canalave-city-area")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = commandExplore(ctx, cfg, "nowhere-area")
+	if err == nil || err.Error() != "no location area named 'nowhere-area'" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCommandCatchNotFound(t *testing.T) {
+	cfg := newTestConfig(t)
+
+	err := commandCatch(context.Background(), cfg, "pikachuu")
+	if err == nil || err.Error() != "no Pokemon named 'pikachuu'" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCommandInspect(t *testing.T) {
+	cfg := newTestConfig(t)
+	ctx := context.Background()
+
+	err := commandInspect(ctx, cfg, "pikachu")
+	if err == nil {
+		t.Errorf("expected error for uncaught pokemon")
+	}
+
+	pokemon, err := cfg.Client.GetPokemonData("pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg.Client.AddToPokedex(pokemon)
+	got, err := captureStdout(t, func() error {
+		return commandInspect(ctx, cfg, "pikachu")
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	want := "Abilities:\n  - static\n  - lightning-rod (hidden)\n"
+	if !strings.HasSuffix(got, want) {
+		t.Errorf("expected inspect to end with\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestCommandSaveLoad(t *testing.T) {
+	cfg := newTestConfig(t)
+	ctx := context.Background()
+	cfg.SavePath = filepath.Join(t.TempDir(), "pokedex.json")
+
+	pokemon, err := cfg.Client.GetPokemonData("pidgey")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg.Client.AddToPokedex(pokemon)
+	err = commandSave(ctx, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg.Client.SetPokedex(nil)
+	err = commandLoad(ctx, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := cfg.Client.GetFromPokedex("pidgey"); !ok {
+		t.Errorf("expected pidgey to be loaded")
+	}
+
+	// Loading another file must not make autosave overwrite the default.
+	defaultPath := cfg.SavePath
+	otherPath := filepath.Join(t.TempDir(), "other.json")
+	pikachu, err := cfg.Client.GetPokemonData("pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := savefile.Save(otherPath, []pokeapi.Pokemon{pikachu}); err != nil {
+		t.Fatal(err)
+	}
+	if err := commandLoad(ctx, cfg, otherPath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.SavePath != otherPath {
+		t.Errorf("expected autosave to follow the loaded file, got %q", cfg.SavePath)
+	}
+	if err := savePokedex(cfg, cfg.SavePath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	saved, err := savefile.Load(defaultPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(saved) != 1 || saved[0].Name != "pidgey" {
+		t.Errorf("expected the default save to still hold pidgey, got %v", saved)
+	}
+}
+
+func TestLoadSaveFile(t *testing.T) {
+	cfg := newTestConfig(t)
+	dir := t.TempDir()
+
+	missing := filepath.Join(dir, "missing.json")
+	if err := loadSaveFile(cfg, missing); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.SavePath != missing {
+		t.Errorf("expected autosave to %s, got %q", missing, cfg.SavePath)
+	}
+
+	cfg.SavePath = ""
+	corrupt := filepath.Join(dir, "pokedex.json")
+	original := []byte(`{"version":99,"pokemon":[]}`)
+	if err := os.WriteFile(corrupt, original, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := loadSaveFile(cfg, corrupt); err == nil {
+		t.Fatalf("expected error for unsupported version")
+	}
+	if cfg.SavePath != "" {
+		t.Errorf("expected autosave to be off, got %q", cfg.SavePath)
+	}
+
+	pokemon, err := cfg.Client.GetPokemonData("pidgey")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg.Client.AddToPokedex(pokemon)
+	if err := savePokedex(cfg, cfg.SavePath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := os.ReadFile(corrupt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, original) {
+		t.Errorf("save file was overwritten: %s", got)
+	}
+}
+
+func TestCommandSpecies(t *testing.T) {
+	cfg := newTestConfig(t)
+	ctx := context.Background()
+
+	got, err := captureStdout(t, func() error {
+		return commandSpecies(ctx, cfg, "pikachu")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The flavor text's line and page breaks come out as single spaces.
+	want := `Name: pikachu (Mouse Pokémon)
+Capture rate: 190
+Base happiness: 50
+Growth rate: medium
+Egg groups:
+  - ground
+  - fairy
+Legendary: false
+Mythical: false
+Evolves from: pichu
+Varieties:
+  - pikachu (default)
+  - pikachu-rock-star
+When several of these POKéMON gather, their electricity could build and cause lightning storms.
+`
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	err = commandSpecies(ctx, cfg, "pikachuu")
+	if err == nil || err.Error() != "no Pokemon named 'pikachuu'" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCommandEvolutions(t *testing.T) {
+	cfg := newTestConfig(t)
+	ctx := context.Background()
+
+	cases := map[string]string{
+		"pikachu": `pichu (baby)
+└─ pikachu [level up, happiness 220] <-
+   └─ raichu [use thunder-stone]
+`,
+		"pidgey": `pidgey <-
+└─ pidgeotto [level 18]
+   └─ pidgeot [level 36]
+`,
+	}
+	for name, want := range cases {
+		got, err := captureStdout(t, func() error {
+			return commandEvolutions(ctx, cfg, name)
+		})
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", name, err)
+		}
+		if got != want {
+			t.Errorf("evolutions %s:\ngot:\n%s\nwant:\n%s", name, got, want)
+		}
+	}
+}
+
+func TestPrintEvolutionsBranches(t *testing.T) {
+	level := func(n int) pokeapi.EvolutionDetail {
+		return pokeapi.EvolutionDetail{Trigger: pokeapi.NamedAPIResource{Name: "level-up"}, MinLevel: &n}
+	}
+	item := func(name string) pokeapi.EvolutionDetail {
+		return pokeapi.EvolutionDetail{
+			Trigger: pokeapi.NamedAPIResource{Name: "use-item"},
+			Item:    &pokeapi.NamedAPIResource{Name: name},
+		}
+	}
+	link := func(name string, evolvesTo []pokeapi.ChainLink, details ...pokeapi.EvolutionDetail) pokeapi.ChainLink {
+		return pokeapi.ChainLink{
+			Species:          pokeapi.NamedAPIResource{Name: name},
+			EvolutionDetails: details,
+			EvolvesTo:        evolvesTo,
+		}
+	}
+	links := []pokeapi.ChainLink{
+		link("gloom", []pokeapi.ChainLink{
+			link("vileplume", nil, item("leaf-stone")),
+			link("bellossom", nil, item("sun-stone")),
+		}, level(21)),
+		link("leafeon", nil, pokeapi.EvolutionDetail{
+			Trigger:  pokeapi.NamedAPIResource{Name: "level-up"},
+			Location: &pokeapi.NamedAPIResource{Name: "eterna-forest"},
+		}, item("leaf-stone")),
+	}
+
+	got, err := captureStdout(t, func() error {
+		printEvolutions(links, "bellossom", "")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `├─ gloom [level 21]
+│  ├─ vileplume [use leaf-stone]
+│  └─ bellossom [use sun-stone] <-
+└─ leafeon [level up, at eterna-forest or use leaf-stone]
+`
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestCommandWeakness(t *testing.T) {
+	cfg := newTestConfig(t)
+	ctx := context.Background()
+
+	cases := map[string]string{
+		"pikachu": `pikachu (electric)
+  2x: ground
+  0.5x: electric, flying, steel
+`,
+		"pidgey": `pidgey (normal/flying)
+  2x: electric, ice, rock
+  0.5x: bug, grass
+  0x: ghost, ground
+`,
+	}
+	for name, want := range cases {
+		got, err := captureStdout(t, func() error {
+			return commandWeakness(ctx, cfg, name)
+		})
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", name, err)
+		}
+		if got != want {
+			t.Errorf("weakness %s:\ngot:\n%s\nwant:\n%s", name, got, want)
+		}
+	}
+
+	err := commandWeakness(ctx, cfg, "missingno")
+	if err == nil || err.Error() != "no Pokemon named 'missingno'" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCommandMoves(t *testing.T) {
+	cfg := newTestConfig(t)
+	ctx := context.Background()
+
+	cases := []struct {
+		args []string
+		want string
+	}{
+		{
+			// Sorted by level, with the status move's missing power shown as "-".
+			args: []string{"pikachu", "--version-group", "red-blue", "--method", "level-up"},
+			want: `Moves for pikachu:
+  - thunder-shock (level 1)
+      electric, special, power 40, accuracy 100, pp 30
+      Has a 10% chance to paralyze the target.
+  - thunder-wave (level 9)
+      electric, status, power -, accuracy 90, pp 20
+      Paralyzes the target.
+  - quick-attack (level 16)
+      normal, physical, power 40, accuracy 100, pp 30
+      Inflicts regular damage with no additional effect.
+`,
+		},
+		{
+			// Without a version group every row names its own.
+			args: []string{"pikachu", "--method=machine"},
+			want: `Moves for pikachu:
+  - thunderbolt (machine, red-blue)
+      electric, special, power 90, accuracy 100, pp 15
+      Has a 10% chance to paralyze the target.
+  - thunderbolt (machine, x-y)
+      electric, special, power 90, accuracy 100, pp 15
+      Has a 10% chance to paralyze the target.
+`,
+		},
+		{
+			args: []string{"--version-group", "x-y", "pikachu"},
+			want: `Moves for pikachu:
+  - thunderbolt (machine)
+      electric, special, power 90, accuracy 100, pp 15
+      Has a 10% chance to paralyze the target.
+  - thunder-shock (level 1)
+      electric, special, power 40, accuracy 100, pp 30
+      Has a 10% chance to paralyze the target.
+  - quick-attack (level 10)
+      normal, physical, power 40, accuracy 100, pp 30
+      Inflicts regular damage with no additional effect.
+`,
+		},
+		{
+			args: []string{"pikachu", "--method", "egg"},
+			want: "pikachu learns no moves that match\n",
+		},
+	}
+	for _, c := range cases {
+		got, err := captureStdout(t, func() error {
+			return commandMoves(ctx, cfg, c.args...)
+		})
+		if err != nil {
+			t.Errorf("unexpected error for %v: %v", c.args, err)
+		}
+		if got != c.want {
+			t.Errorf("moves %v:\ngot:\n%s\nwant:\n%s", c.args, got, c.want)
+		}
+	}
+
+	for _, args := range [][]string{
+		{},
+		{"pikachu", "--method", "sketch"},
+		{"pikachu", "--version-group"},
+		{"pikachu", "--level", "5"},
+		{"missingno"},
+	} {
+		if err := commandMoves(ctx, cfg, args...); err == nil {
+			t.Errorf("expected error for %v", args)
+		}
+	}
+}
+
+func TestCommandAbility(t *testing.T) {
+	cfg := newTestConfig(t)
+	ctx := context.Background()
+
+	cases := map[string]string{
+		"static": `Name: static
+Generation: generation-iii
+Whenever a move makes contact with this Pokémon, the move's user has a 30% chance of being paralyzed. Pokémon that are immune to electric-type moves can still be paralyzed by this ability.
+Pokemon:
+  - pikachu
+`,
+		"31": `Name: lightning-rod
+Generation: generation-iii
+All other Pokémon's single-target electric-type moves are redirected to this Pokémon. Electric-type moves cannot affect this Pokémon; instead, they raise its Special Attack by one stage.
+Pokemon:
+  - pikachu (hidden)
+`,
+	}
+	for name, want := range cases {
+		got, err := captureStdout(t, func() error {
+			return commandAbility(ctx, cfg, name)
+		})
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", name, err)
+		}
+		if got != want {
+			t.Errorf("ability %s:\ngot:\n%s\nwant:\n%s", name, got, want)
+		}
+	}
+
+	err := commandAbility(ctx, cfg, "wonder-guard")
+	if err == nil || err.Error() != "no ability named 'wonder-guard'" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}